@@ -0,0 +1,201 @@
+/*
+Copyright © 2021 Aatman <aatman@auroville.org.in>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and edit the gojen config",
+}
+
+// configShowCmd represents the config show command
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective config and where each value came from",
+	Long: `Print every value of the effective config, after merging the files listed
+in extends and applying --set, along with the config file it was set in.
+
+With --resolved the defaults of fields that are not configured are printed as
+well, so the output shows exactly what gojen will use.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		proj, err := project.GetConfig()
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		err = proj.ValidateConfig()
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		file, err := proj.ConfigFile()
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		dir := filepath.Dir(file)
+
+		settings := proj.Settings()
+		if resolved {
+			settings, err = proj.Resolved()
+			if err != nil {
+				fmt.Println(err.Error())
+				os.Exit(1)
+			}
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+		for _, s := range settings {
+			source := s.Source
+			if s.Source == project.SourceFile {
+				source, err = filepath.Rel(dir, s.File)
+				if err != nil {
+					source = s.File
+				}
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", s.Path, s.Value, source)
+		}
+		w.Flush()
+	},
+}
+
+var resolved bool
+
+// configGetCmd represents the config get command
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a value of the effective config",
+	Long: `Print a value of the effective config, e.g.
+
+$ gojen config get workflowEnv.FOO
+
+Lists and objects are printed as JSON.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		proj, err := project.GetConfig()
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		value, err := proj.Get(args[0])
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		fmt.Println(value)
+	},
+}
+
+// configSetCmd represents the config set command
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a value in the config file",
+	Long: `Set a value in the config file, e.g.
+
+$ gojen config set goTest false
+$ gojen config set workflowEnv.FOO bar
+$ gojen config set goTestArgs '[-v, ./...]'
+
+Values of string fields are taken as is, anything else is parsed as YAML.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		editConfig(func(proj *project.Project) error {
+			return proj.Set(args[0], args[1])
+		})
+	},
+}
+
+// configUnsetCmd represents the config unset command
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a value from the config file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		editConfig(func(proj *project.Project) error {
+			return proj.Unset(args[0])
+		})
+	},
+}
+
+// configAddCmd represents the config add command
+var configAddCmd = &cobra.Command{
+	Use:   "add <key> <value>",
+	Short: "Append a value to a list in the config file",
+	Long: `Append a value to a list in the config file, unless the list already
+contains it, e.g.
+
+$ gojen config add gitignore dist/`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		editConfig(func(proj *project.Project) error {
+			return proj.Add(args[0], args[1])
+		})
+	},
+}
+
+// editConfig applies edit to the config file, and writes it back if the
+// result is valid.
+func editConfig(edit func(proj *project.Project) error) {
+	proj, err := project.GetConfig()
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	err = edit(proj)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	err = proj.ValidateConfig()
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	err = proj.WriteConfig()
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configAddCmd)
+
+	configShowCmd.Flags().BoolVar(&resolved, "resolved", false, "Include the defaults of fields that are not configured")
+}
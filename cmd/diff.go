@@ -0,0 +1,67 @@
+/*
+Copyright © 2021 Aatman <aatman@auroville.org.in>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show how the generated files differ from the ones on disk",
+	Long: `Generate the project files in memory and print a unified diff of every
+file that would be created or changed, without writing anything.
+
+Exits with 1 when there are differences, so it can be used as a check in CI.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		proj, err := project.InitProject()
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		changes, err := proj.Diff()
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		color := !noColor && isTerminal(os.Stdout)
+		err = project.WriteDiff(os.Stdout, changes, color)
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		if len(changes) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var noColor bool
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().BoolVar(&noColor, "no-color", false, "Do not color the diff")
+}
@@ -0,0 +1,158 @@
+/*
+Copyright © 2021 Aatman <aatman@auroville.org.in>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// configFlags maps the fields of project.Project to flags named after their
+// json keys in lower case, e.g. --goversion for goVersion.
+type configFlags struct {
+	values map[string]reflect.Value
+	fields map[string]int
+}
+
+// newConfigFlags adds a flag for every field of project.Project to flags,
+// defaulting to the value the field has when it is not configured.
+func newConfigFlags(flags *pflag.FlagSet, skip ...string) *configFlags {
+	c := &configFlags{
+		values: map[string]reflect.Value{},
+		fields: map[string]int{},
+	}
+
+	t := reflect.TypeOf(project.Project{})
+	defaults := reflect.ValueOf(project.Defaults()).Elem()
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.PkgPath != "" || key == "" || project.Contains(skip, key) {
+			continue
+		}
+
+		name := strings.ToLower(key)
+		usage := project.FieldDescription(key)
+		value := reflect.New(f.Type.Elem())
+		if !defaults.Field(i).IsNil() {
+			value.Elem().Set(defaults.Field(i).Elem())
+		}
+
+		switch p := value.Interface().(type) {
+		case *string:
+			flags.StringVar(p, name, *p, usage)
+		case *bool:
+			flags.BoolVar(p, name, *p, usage)
+		case *[]string:
+			flags.StringSliceVar(p, name, *p, usage)
+		default:
+			flags.Var(&yamlValue{value: value}, name, usage+" (YAML)")
+		}
+
+		c.values[name] = value
+		c.fields[name] = i
+	}
+
+	return c
+}
+
+// apply sets every field of proj to the value of its flag, so a new config
+// spells out the defaults of the fields that were not given. Those follow
+// the preset flag when it is given, and fields without a default, such as
+// extends, are left out.
+func (c *configFlags) apply(proj *project.Project, flags *pflag.FlagSet) {
+	v := reflect.ValueOf(proj).Elem()
+
+	preset := ""
+	if value, ok := c.values["preset"]; ok {
+		preset = value.Elem().String()
+	}
+	defaults := reflect.ValueOf(project.PresetDefaults(preset)).Elem()
+
+	for name, value := range c.values {
+		field := v.Field(c.fields[name])
+		if flags.Changed(name) {
+			field.Set(value)
+		} else if !defaults.Field(c.fields[name]).IsNil() {
+			field.Set(defaults.Field(c.fields[name]))
+		}
+	}
+}
+
+// given reports whether any of the config flags, other than the preset, was
+// set on the command line.
+func (c *configFlags) given(flags *pflag.FlagSet) bool {
+	for name := range c.values {
+		if flags.Changed(name) && name != "preset" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// yamlValue is a flag for fields without a flag type of their own, such as
+// workflowEnv, prependSteps or hooks, its value is parsed as YAML.
+type yamlValue struct {
+	value reflect.Value
+}
+
+func (y *yamlValue) String() string {
+	if !y.value.IsValid() {
+		return ""
+	}
+
+	switch v := y.value.Elem(); v.Kind() {
+	case reflect.Struct:
+		if v.IsZero() {
+			return ""
+		}
+	default:
+		if v.Len() == 0 {
+			return ""
+		}
+	}
+
+	b, err := yaml.Marshal(y.value.Interface())
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(b))
+}
+
+func (y *yamlValue) Set(s string) error {
+	v := reflect.New(y.value.Type().Elem())
+
+	err := yaml.Unmarshal([]byte(s), v.Interface())
+	if err != nil {
+		return fmt.Errorf("invalid YAML: %s", err.Error())
+	}
+
+	y.value.Elem().Set(v.Elem())
+
+	return nil
+}
+
+func (y *yamlValue) Type() string {
+	return "yaml"
+}
@@ -27,60 +27,93 @@ import (
 
 var cfg project.Project
 
+var format string
+
+var newFlags *configFlags
+
 // newCmd represents the new command
 var newCmd = &cobra.Command{
 	Use:   "new",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
+	Short: "Create a new project",
+	Long: `Write a config for a new project from the given flags and set the project
+up, or set up the existing project when a config already exists.
 
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
+When run on a terminal without any config flags, gojen new asks for the
+settings of the project instead.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
 		pwd, err := os.Getwd()
 		if err != nil {
-			fmt.Println(err.Error())
+			fmt.Fprintln(project.TextOutput(), err.Error())
 		}
 
-		if _, err := os.Stat(pwd + "/gojen.json"); errors.Is(err, os.ErrNotExist) {
-			err := cfg.WriteConfig()
+		if _, err := project.FindConfig(pwd); errors.Is(err, project.ErrNoConfig) {
+			newFlags.apply(&cfg, cmd.Flags())
+
+			err := cfg.SetConfigFormat(format)
 			if err != nil {
-				fmt.Println(err.Error())
+				fmt.Fprintln(project.TextOutput(), err.Error())
 				os.Exit(1)
 			}
-			cmd.Println("config written")
+			cfg.Schema = project.String(project.SchemaURL)
+
+			if !project.CI && !newFlags.given(cmd.Flags()) && isTerminal(os.Stdin) {
+				ok, err := cfg.Wizard(os.Stdin, os.Stdout, pwd)
+				if err != nil {
+					fmt.Fprintln(project.TextOutput(), err.Error())
+					os.Exit(1)
+				}
+
+				if !ok {
+					fmt.Fprintln(project.TextOutput(), "aborted, no config written")
+					os.Exit(1)
+				}
+			}
 
-			proj, err := project.InitProject()
+			err = cfg.WriteConfig()
 			if err != nil {
-				fmt.Println(err.Error())
+				fmt.Fprintln(project.TextOutput(), err.Error())
 				os.Exit(1)
 			}
+			if !project.DryRun {
+				cmd.Println("config written")
+			}
 
-			err = proj.SetupProject()
-			if err != nil {
-				if err.Error() == "logged to stderr" {
+			// in a dry run the config was not written, so set up cfg as is
+			var proj project.IProject = &cfg
+			if !project.DryRun {
+				proj, err = project.InitProject()
+				if err != nil {
+					fmt.Fprintln(project.TextOutput(), err.Error())
 					os.Exit(1)
 				}
-				fmt.Println(err.Error())
-				os.Exit(1)
 			}
 
-			fmt.Printf("initialized new project with name %s\n", cfg.GetName())
+			proj.SetContext(interruptContext())
+			err = proj.SetupProject()
+			summarize(proj)
+			if err != nil {
+				exit(err)
+			}
+
+			if !project.DryRun {
+				fmt.Fprintf(project.TextOutput(), "initialized new project with name %s\n", cfg.GetName())
+			}
 			return
 		}
 
-		fmt.Println("config already exists")
+		fmt.Fprintln(project.TextOutput(), "config already exists")
 
 		proj, err := project.InitProject()
 		if err != nil {
 			log.Fatal(err.Error())
 		}
 
+		proj.SetContext(interruptContext())
 		err = proj.SetupProject()
+		summarize(proj)
 		if err != nil {
-			log.Fatal(err.Error())
+			exit(err)
 		}
 
 	},
@@ -97,19 +130,17 @@ func init() {
 
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
-	cfg.Name = newCmd.Flags().String("name", "", "name of your project")
-	cfg.Description = newCmd.Flags().String("description", "", "description of your project")
-	cfg.Repository = newCmd.Flags().String("repository", "", "repository URL for your project")
-	cfg.GoVersion = newCmd.Flags().String("goversion", "1.16", "go version")
-	cfg.AuthorName = newCmd.Flags().String("authorname", "", "name of Author")
-	cfg.AuthorEmail = newCmd.Flags().String("authoremail", "", "email of Author")
-	cfg.AuthorOrganization = newCmd.Flags().String("authororganization", "", "author github organization")
-	cfg.Release = newCmd.Flags().Bool("release", false, "setup go-semantic-release and upload binaries")
-	cfg.DefaultReleaseBranch = newCmd.Flags().String("defaultreleasebranch", "master", "default branch to release from")
-	cfg.Gitignore = newCmd.Flags().StringSlice("gitignore", []string{}, "list of gitignore values")
-	cfg.CodeOwners = newCmd.Flags().StringSlice("codeowners", []string{}, "list of codeowner values")
-	cfg.GoLinter = newCmd.Flags().Bool("golinter", false, "enable golinter")
-	cfg.GoTest = newCmd.Flags().Bool("gotest", true, "enable go test")
-	cfg.License = newCmd.Flags().String("license", "", "license name")
-	cfg.GoTestArgs = newCmd.Flags().StringSlice("gotestargs", []string{}, "arguments for go test")
+	newFlags = newConfigFlags(newCmd.Flags(), "$schema")
+	newCmd.Flags().StringVar(&format, "format", project.FormatJSON, "config file format, json or yaml")
+	addRunFlags(newCmd.Flags())
+}
+
+// isTerminal reports whether f is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
 }
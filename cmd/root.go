@@ -16,12 +16,17 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	project "github.com/Hunter-Thompson/gojen/pkg/project"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -34,38 +39,91 @@ examples and usage of using your application. For example:
 Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if project.Output != project.OutputText && project.Output != project.OutputJSON {
+			return fmt.Errorf("unknown output %q, expected %s or %s", project.Output, project.OutputText, project.OutputJSON)
+		}
+		if project.Jobs < 1 {
+			return fmt.Errorf("--jobs must be at least 1, got %d", project.Jobs)
+		}
+		return nil
+	},
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	Run: func(cmd *cobra.Command, args []string) {
 		pwd, err := os.Getwd()
 		if err != nil {
-			fmt.Println(err.Error())
+			fmt.Fprintln(project.TextOutput(), err.Error())
 			os.Exit(1)
 		}
 
-		if _, err := os.Stat(pwd + "/gojen.json"); errors.Is(err, os.ErrNotExist) {
-			fmt.Println("gojen.json does not exist in current folder, initialise one using\n\n$ gojen new")
+		if _, err := project.FindConfig(pwd); err != nil {
+			if errors.Is(err, project.ErrNoConfig) {
+				names := project.ConfigFiles
+				fmt.Fprintf(project.TextOutput(), "none of %s or %s exist in current folder, initialise one using\n\n$ gojen new\n",
+					strings.Join(names[:len(names)-1], ", "), names[len(names)-1])
+				os.Exit(1)
+			}
+			fmt.Fprintln(project.TextOutput(), err.Error())
 			os.Exit(1)
 		}
 
 		proj, err := project.InitProject()
 
 		if err != nil {
-			fmt.Println(err.Error())
+			fmt.Fprintln(project.TextOutput(), err.Error())
 			os.Exit(1)
 		}
 
+		proj.SetContext(interruptContext())
 		err = proj.SetupProject()
+		summarize(proj)
 		if err != nil {
-			if err.Error() == "logged to stderr" {
-				os.Exit(1)
-			}
-			fmt.Println(err.Error())
-			os.Exit(1)
+			exit(err)
 		}
 	},
 }
 
+// exit exits with the exit code of the stage err failed in. The failure was
+// logged already, so only the failing stage is printed as a summary.
+func exit(err error) {
+	var stageErr *project.StageError
+	if errors.As(err, &stageErr) {
+		fmt.Fprintln(os.Stderr, err.Error())
+	} else {
+		fmt.Fprintln(project.TextOutput(), err.Error())
+	}
+
+	os.Exit(project.ExitCode(err))
+}
+
+// interruptContext returns a context that is done once gojen receives SIGINT
+// or SIGTERM, which stops the commands running. A second signal exits right
+// away.
+func interruptContext() context.Context {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	return ctx
+}
+
+// summarize prints how long every stage took, and why stages were skipped,
+// after a run with text output.
+func summarize(proj project.IProject) {
+	if project.Output != project.OutputText || project.DryRun || len(proj.Results()) == 0 {
+		return
+	}
+
+	fmt.Println()
+	err := project.WriteSummary(os.Stdout, proj.Results())
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -73,5 +131,22 @@ func Execute() {
 }
 
 func init() {
+	// --set applies to every command loading the config, e.g. config show
 	rootCmd.PersistentFlags().BoolVarP(&project.CI, "ci", "c", false, "Run in CI mode")
+	rootCmd.PersistentFlags().StringArrayVar(&project.Overrides, "set", nil, "Override a config value, e.g. --set goTest=false (can be repeated)")
+	addRunFlags(rootCmd.Flags())
+}
+
+// addRunFlags adds the flags that change how the stages run to the flags of
+// a command running them.
+func addRunFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&project.DryRun, "dry-run", false, "Print the files gojen would write and the commands it would run, without doing either")
+	flags.BoolVar(&project.Check, "check", false, "Verify that generated files, formatting, go.mod, go.sum and vendor are up to date instead of updating them")
+	flags.StringVarP(&project.Output, "output", "o", project.OutputText, "Report progress as text, or as json events one per line")
+	flags.StringSliceVar(&project.Only, "only", nil, "Run only these stages, e.g. --only test, even when the config skips them")
+	flags.StringSliceVar(&project.Skip, "skip", nil, "Skip these stages, e.g. --skip vendor,lint")
+	flags.BoolVar(&project.Parallel, "parallel", false, "Run the stages that do not depend on each other at the same time")
+	flags.IntVarP(&project.Jobs, "jobs", "j", project.Jobs, "Run at most this many stages at the same time with --parallel")
+	flags.BoolVar(&project.RunAll, "run-all", false, "Keep running the stages that do not depend on a failed stage, instead of stopping at the first failure")
+	flags.BoolVar(&project.NoCache, "no-cache", false, "Run every stage, even the ones whose inputs did not change since they last passed")
 }
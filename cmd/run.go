@@ -0,0 +1,63 @@
+/*
+Copyright © 2021 Aatman <aatman@auroville.org.in>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run [task]",
+	Short: "Run a task and the tasks it depends on",
+	Long: `Run a task after the tasks it depends on, e.g.
+
+$ gojen run test
+
+runs go mod vendor, tidy and fmt before go test. Without a task the default
+task is run, which is what running gojen does. List the tasks using
+
+$ gojen tasks`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		task := project.DefaultTask
+		if len(args) > 0 {
+			task = args[0]
+		}
+
+		proj, err := project.InitProject()
+		if err != nil {
+			fmt.Fprintln(project.TextOutput(), err.Error())
+			os.Exit(1)
+		}
+
+		proj.SetContext(interruptContext())
+		err = proj.RunTask(task)
+		summarize(proj)
+		if err != nil {
+			exit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	addRunFlags(runCmd.Flags())
+}
@@ -0,0 +1,47 @@
+/*
+Copyright © 2021 Aatman <aatman@auroville.org.in>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of gojen.json",
+	Long: `Print the JSON Schema of gojen.json, generated from the config struct.
+
+Point your editor at it to get autocompletion and validation, configs created
+with "gojen new" reference the published schema through their $schema key.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		b, err := project.Schema()
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		fmt.Print(string(b))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
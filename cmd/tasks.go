@@ -0,0 +1,50 @@
+/*
+Copyright © 2021 Aatman <aatman@auroville.org.in>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+// tasksCmd represents the tasks command
+var tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "List the tasks gojen run can run",
+	Run: func(cmd *cobra.Command, args []string) {
+		proj, err := project.InitProject()
+		if err != nil {
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "TASK\tDEPENDS ON\tDESCRIPTION")
+		for _, t := range proj.AllTasks() {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", t.Name, strings.Join(t.DependsOn, ", "), t.Description)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tasksCmd)
+}
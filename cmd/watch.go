@@ -0,0 +1,70 @@
+/*
+Copyright © 2021 Aatman <aatman@auroville.org.in>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Rerun the stages affected by every change to the project",
+	Long: `Poll the Go sources, go.mod, go.sum and the config file, and once they stop
+changing rerun the stages the change affects:
+
+  changes to tests and test data run go test
+  changes to other Go sources run go test and go build
+  changes to go.mod and go.sum run go mod vendor and tidy, go test and go build
+  changes to the config generate the workflows and other files again
+
+Stages the config skips are not run. Stop watching with Ctrl-C.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		proj, err := project.GetConfig()
+		if err != nil {
+			fmt.Fprintln(project.TextOutput(), err.Error())
+			os.Exit(1)
+		}
+
+		err = proj.ValidateConfig()
+		if err != nil {
+			fmt.Fprintln(project.TextOutput(), err.Error())
+			os.Exit(1)
+		}
+
+		// an interrupt stops the stages running as well as watching
+		ctx := interruptContext()
+		proj.SetContext(ctx)
+
+		err = proj.Watch(ctx.Done(), isTerminal(os.Stdout))
+		if err != nil {
+			fmt.Fprintln(project.TextOutput(), err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	addRunFlags(watchCmd.Flags())
+	watchCmd.Flags().DurationVar(&project.WatchInterval, "interval", project.WatchInterval, "How often to poll the files for changes")
+	watchCmd.Flags().DurationVar(&project.WatchDebounce, "debounce", project.WatchDebounce, "How long the files must stay unchanged before the stages run")
+}
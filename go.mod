@@ -5,13 +5,14 @@ go 1.17
 require (
 	github.com/bradleyjkemp/cupaloy/v2 v2.7.0
 	github.com/kyokomi/emoji/v2 v2.2.8
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/spf13/cobra v1.2.1
+	github.com/spf13/pflag v1.0.5
 	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
 )
@@ -405,37 +405,37 @@ type JobStep struct {
 	// Set to true to allow a job
 	// to pass when this step fails.
 	// Experimental.
-	ContinueOnError *bool `yaml:"continue-on-error,omitempty"`
+	ContinueOnError *bool `yaml:"continue-on-error,omitempty" json:"continue-on-error,omitempty"`
 	// Sets environment variables for steps to use in the runner environment.
 	//
 	// You can also set environment variables for the entire workflow or a job.
 	// Experimental.
-	Env *map[string]*string `yaml:"env,omitempty"`
+	Env *map[string]*string `yaml:"env,omitempty" json:"env,omitempty"`
 	// A unique identifier for the step.
 	//
 	// You can use the id to reference the
 	// step in contexts.
 	// Experimental.
-	Id *string `yaml:"id,omitempty"`
+	Id *string `yaml:"id,omitempty" json:"id,omitempty"`
 	// You can use the if conditional to prevent a job from running unless a condition is met.
 	//
 	// You can use any supported context and expression to
 	// create a conditional.
 	// Experimental.
-	If *string `yaml:"if,omitempty"`
+	If *string `yaml:"if,omitempty" json:"if,omitempty"`
 	// A name for your step to display on GitHub.
 	// Experimental.
-	Name *string `yaml:"name,omitempty"`
+	Name *string `yaml:"name,omitempty" json:"name,omitempty"`
 	// Runs command-line programs using the operating system's shell.
 	//
 	// If you do
 	// not provide a name, the step name will default to the text specified in
 	// the run command.
 	// Experimental.
-	Run *string `yaml:"run,omitempty"`
+	Run *string `yaml:"run,omitempty" json:"run,omitempty"`
 	// The maximum number of minutes to run the step before killing the process.
 	// Experimental.
-	TimeoutMinutes *float64 `yaml:"timeout-minutes,omitempty"`
+	TimeoutMinutes *float64 `yaml:"timeout-minutes,omitempty" json:"timeout-minutes,omitempty"`
 	// Selects an action to run as part of a step in your job.
 	//
 	// An action is a
@@ -443,14 +443,14 @@ type JobStep struct {
 	// repository as the workflow, a public repository, or in a published Docker
 	// container image.
 	// Experimental.
-	Uses *string `yaml:"uses,omitempty"`
+	Uses *string `yaml:"uses,omitempty" json:"uses,omitempty"`
 	// A map of the input parameters defined by the action.
 	//
 	// Each input parameter
 	// is a key/value pair. Input parameters are set as environment variables.
 	// The variable is prefixed with INPUT_ and converted to upper case.
 	// Experimental.
-	With *map[string]interface{} `yaml:"with,omitempty"`
+	With *map[string]interface{} `yaml:"with,omitempty" json:"with,omitempty"`
 }
 
 // An output binding for a job.
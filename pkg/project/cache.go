@@ -0,0 +1,207 @@
+package project
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// NoCache runs every stage, even the ones whose inputs did not change since
+// they last passed.
+var NoCache bool
+
+// cachedReason is why a stage whose inputs did not change is skipped.
+const cachedReason = "inputs unchanged since the last run"
+
+// cacheDir returns the directory the hashes of the inputs of the stages of
+// the project in the current directory are kept in.
+func cacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(pwd))
+
+	return filepath.Join(dir, "gojen", hex.EncodeToString(sum[:8])), nil
+}
+
+// cacheEnabled reports whether t can be skipped when its inputs did not
+// change. Stages run with a runner set do not run the real commands, so they
+// are never cached.
+func (proj *Project) cacheEnabled(t *Task) bool {
+	return t.cache && !NoCache && !DryRun && !Check && proj.runner == nil
+}
+
+// cached reports whether the inputs of t are the same as when it last passed.
+func (proj *Project) cached(t *Task) bool {
+	if !proj.cacheEnabled(t) {
+		return false
+	}
+
+	dir, err := cacheDir()
+	if err != nil {
+		return false
+	}
+
+	stored, err := ioutil.ReadFile(filepath.Join(dir, t.Name))
+	if err != nil {
+		return false
+	}
+
+	hash, err := proj.inputHash(t)
+	if err != nil {
+		return false
+	}
+
+	return string(stored) == hash
+}
+
+// storeCache records the inputs t passed with, so the next run can skip it
+// when they do not change. A cache that cannot be written must not fail the
+// run, the stage runs again next time.
+func (proj *Project) storeCache(t *Task) {
+	if !proj.cacheEnabled(t) {
+		return
+	}
+
+	dir, err := cacheDir()
+	if err != nil {
+		return
+	}
+
+	hash, err := proj.inputHash(t)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	_ = ioutil.WriteFile(filepath.Join(dir, t.Name), []byte(hash), 0644)
+}
+
+// inputHash hashes everything the result of t depends on: the config, which
+// holds the arguments of the commands, the environment of go test, the
+// versions of the tools t runs, and the Go sources, go.mod, go.sum and test
+// data of the project, along with the files t writes.
+func (proj *Project) inputHash(t *Task) (string, error) {
+	h := sha256.New()
+
+	config, err := json.Marshal(proj)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(h, "task %s\nci %t\nconfig %s\n", t.Name, CI, config)
+
+	// the testEnvFile is not one of the input files, as it is ignored by git
+	env, err := proj.testEnvInputs()
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "env %s\n", strings.Join(env, "\n"))
+
+	for _, tool := range t.tools {
+		fmt.Fprintf(h, "tool %s\n%s\n", strings.Join(tool, " "), toolVersion(tool))
+	}
+
+	files, err := inputFiles()
+	if err != nil {
+		return "", err
+	}
+
+	if t.outputs != nil {
+		files = append(files, t.outputs(proj)...)
+	}
+
+	for _, file := range files {
+		f, err := os.Open(file)
+		if os.IsNotExist(err) {
+			// an output that was removed only matches one that was never
+			// written
+			fmt.Fprintf(h, "missing %s\n", filepath.ToSlash(file))
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(h, "file %s\n", filepath.ToSlash(file))
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// toolVersion returns the output of the command printing the version of a
+// tool, a tool that is not installed has no version.
+func toolVersion(tool []string) string {
+	var out bytes.Buffer
+	c := &Command{Name: tool[0], Args: tool[1:], Stdout: &out, Stderr: &out}
+	if err := (ExecRunner{}).Run(c); err != nil {
+		return ""
+	}
+
+	return out.String()
+}
+
+// inputFiles returns the files of the project in the current directory that
+// the stages read, sorted. Hidden directories are left out, and only the
+// modules.txt of the vendor directory is, as it lists what was vendored.
+func inputFiles() ([]string, error) {
+	files := []string{}
+	err := filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := info.Name()
+		if info.IsDir() {
+			if path != "." && (strings.HasPrefix(name, ".") || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		inTestdata := false
+		for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(path)), "/") {
+			inTestdata = inTestdata || dir == "testdata"
+		}
+
+		if strings.HasSuffix(name, ".go") || path == "go.mod" || path == "go.sum" || inTestdata {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(filepath.Join("vendor", "modules.txt")); err == nil {
+		files = append(files, filepath.Join("vendor", "modules.txt"))
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
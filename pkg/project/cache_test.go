@@ -0,0 +1,238 @@
+package project_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":       "module github.com/test/test\n\ngo 1.17\n",
+		"main.go":      "package main\n\nfunc main() {}\n",
+		"main_test.go": "package main\n\nimport \"testing\"\n\nfunc TestRun(t *testing.T) {}\n",
+	})
+	chdir(t, dir)
+
+	// keep the build cache of go where it is, only the cache of gojen moves
+	if os.Getenv("GOCACHE") == "" {
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("GOCACHE", filepath.Join(cache, "go-build"))
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	project.Only = []string{"test"}
+	t.Cleanup(func() {
+		project.Only = nil
+		project.NoCache = false
+	})
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+	}
+
+	testResult := func() *project.StageResult {
+		t.Helper()
+
+		err := p.RunTask("test")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range p.Results() {
+			if r.Task == "test" {
+				return r
+			}
+		}
+
+		t.Fatal("expected a result for test")
+		return nil
+	}
+
+	if r := testResult(); r.Status != project.StatusPassed {
+		t.Fatalf("expected test to run the first time, got %+v", r)
+	}
+
+	r := testResult()
+	if r.Status != project.StatusSkipped || r.Reason != "inputs unchanged since the last run" {
+		t.Errorf("expected test to be skipped when nothing changed, got %+v", r)
+	}
+
+	// with JSON output the stageSkipped event is the only report of the skip
+	var events bytes.Buffer
+	project.Output, project.Events = project.OutputJSON, &events
+	stdout, _ := captureOutput(t, func() {
+		r = testResult()
+	})
+	project.Output, project.Events = project.OutputText, os.Stdout
+	if r.Status != project.StatusSkipped {
+		t.Errorf("expected test to be skipped, got %+v", r)
+	}
+	if stdout != "" {
+		t.Errorf("expected nothing but events with JSON output, got %q", stdout)
+	}
+	if !strings.Contains(events.String(), `"reason":"inputs unchanged since the last run"`) {
+		t.Errorf("expected a stageSkipped event for test, got:\n%s", events.String())
+	}
+
+	project.NoCache = true
+	if r := testResult(); r.Status != project.StatusPassed {
+		t.Errorf("expected --no-cache to run test, got %+v", r)
+	}
+	project.NoCache = false
+
+	err := ioutil.WriteFile("main.go", []byte("package main\n\nfunc main() { println() }\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r := testResult(); r.Status != project.StatusPassed {
+		t.Errorf("expected test to run after main.go changed, got %+v", r)
+	}
+
+	p.GoTestArgs = project.StringSlice([]string{"-count=1"})
+	if r := testResult(); r.Status != project.StatusPassed {
+		t.Errorf("expected test to run after its arguments changed, got %+v", r)
+	}
+
+	// go test inherits the testEnvVars without a value, and the variables
+	// of the testEnvFile set already, from the environment
+	writeFiles(t, dir, map[string]string{".env": "DB_USER=gojen\n"})
+	p.TestEnvVars = project.StringSlice([]string{"API_TOKEN"})
+	p.TestEnvFile = project.String(".env")
+	t.Setenv("API_TOKEN", "old")
+	t.Setenv("DB_USER", "old")
+	if r := testResult(); r.Status != project.StatusPassed {
+		t.Errorf("expected test to run after its environment changed, got %+v", r)
+	}
+
+	for _, key := range []string{"API_TOKEN", "DB_USER"} {
+		t.Setenv(key, "new")
+		if r := testResult(); r.Status != project.StatusPassed {
+			t.Errorf("expected test to run after %s changed, got %+v", key, r)
+		}
+		if r := testResult(); r.Status != project.StatusSkipped {
+			t.Errorf("expected test to be skipped when nothing changed, got %+v", r)
+		}
+	}
+
+	os.Unsetenv("API_TOKEN")
+	if r := testResult(); r.Status != project.StatusPassed {
+		t.Errorf("expected test to run after API_TOKEN was unset, got %+v", r)
+	}
+
+	// the coverage written by go test is checked along with its inputs
+	p.CodeCov = project.Bool(true)
+	if r := testResult(); r.Status != project.StatusPassed {
+		t.Errorf("expected test to run after codeCov changed, got %+v", r)
+	}
+	if r := testResult(); r.Status != project.StatusSkipped {
+		t.Errorf("expected test to be skipped when nothing changed, got %+v", r)
+	}
+
+	err = os.Remove("coverage.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r := testResult(); r.Status != project.StatusPassed {
+		t.Errorf("expected test to run after coverage.txt was removed, got %+v", r)
+	}
+
+	// the binary written by go build is checked along with its inputs
+	project.Only = []string{"build"}
+	buildResult := func() *project.StageResult {
+		t.Helper()
+
+		err := p.RunTask("build")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range p.Results() {
+			if r.Task == "build" {
+				return r
+			}
+		}
+
+		t.Fatal("expected a result for build")
+		return nil
+	}
+
+	if r := buildResult(); r.Status != project.StatusPassed {
+		t.Fatalf("expected build to run the first time, got %+v", r)
+	}
+	if r := buildResult(); r.Status != project.StatusSkipped {
+		t.Errorf("expected build to be skipped when nothing changed, got %+v", r)
+	}
+
+	err = os.Remove("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r := buildResult(); r.Status != project.StatusPassed {
+		t.Errorf("expected build to run after the binary was removed, got %+v", r)
+	}
+
+	p.GoBuildArgs = project.StringSlice([]string{"-o", "bin/app"})
+	if r := buildResult(); r.Status != project.StatusPassed {
+		t.Errorf("expected build to run after its arguments changed, got %+v", r)
+	}
+
+	err = os.Remove(filepath.Join("bin", "app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r := buildResult(); r.Status != project.StatusPassed {
+		t.Errorf("expected build to run after the binary given to -o was removed, got %+v", r)
+	}
+}
+
+// captureOutput returns what f writes to os.Stdout and os.Stderr.
+func captureOutput(t *testing.T, f func()) (string, string) {
+	t.Helper()
+
+	files := []**os.File{&os.Stdout, &os.Stderr}
+	readers := []*os.File{}
+	writers := []*os.File{}
+	for _, file := range files {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		prev := *file
+		*file = w
+		defer func(file **os.File) {
+			*file = prev
+		}(file)
+
+		readers, writers = append(readers, r), append(writers, w)
+	}
+
+	f()
+
+	output := []string{}
+	for i, r := range readers {
+		writers[i].Close()
+
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		output = append(output, string(b))
+	}
+
+	return output[0], output[1]
+}
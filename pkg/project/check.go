@@ -0,0 +1,305 @@
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Check makes gojen verify that the generated files, formatting, go.mod,
+// go.sum and vendor directory are up to date instead of updating them.
+var Check bool
+
+// errCheckFailed is wrapped by the errors of the checks, after they logged
+// the files that are out of date. RunTask carries on with the other tasks
+// when it sees it, so every problem is reported at once.
+var errCheckFailed = errors.New("files are out of date")
+
+// checkFailed logs problem and the offending files, and returns a StageError
+// for stage wrapping errCheckFailed. It returns nil when there are no files.
+func (proj *Project) checkFailed(stage string, problem string, files []string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	LogFail(proj.stderr(), problem, "Check")
+	for _, f := range files {
+		fmt.Fprintf(proj.stderr(), "    %s\n", f)
+	}
+
+	return &StageError{Stage: stage, Err: fmt.Errorf("%w: %s", errCheckFailed, strings.Join(files, ", "))}
+}
+
+// CheckGenerated fails when a file generated by gojen would be created,
+// changed or removed.
+func (proj *Project) CheckGenerated() error {
+	LogInfo(proj.stdout(), "checking generated files", "Check")
+
+	changes, err := proj.Diff()
+	if err != nil {
+		return err
+	}
+
+	files := []string{}
+	for _, c := range changes {
+		switch c.Status {
+		case FileCreated:
+			files = append(files, relPath(c.Path)+" (missing)")
+		case FileRemoved:
+			files = append(files, relPath(c.Path)+" (stale)")
+		default:
+			files = append(files, relPath(c.Path))
+		}
+	}
+
+	return proj.checkFailed(StageGenerate, "generated files are out of date, run gojen to update them", files)
+}
+
+// CheckModule fails when the project has no go.mod.
+func (proj *Project) CheckModule() error {
+	if _, err := os.Stat("go.mod"); errors.Is(err, os.ErrNotExist) {
+		return proj.checkFailed(StageSetup, "go.mod is missing, run gojen to create it", []string{"go.mod"})
+	}
+
+	return nil
+}
+
+// CheckFmt fails when gofmt -l reports a go file outside of vendor.
+func (proj *Project) CheckFmt() error {
+	LogInfo(proj.stdout(), "running gofmt -l", "Check")
+
+	files, err := goFiles(".")
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	var out bytes.Buffer
+	gofmt := proj.command("gofmt", append([]string{"-l"}, files...)...)
+	gofmt.Stdout = &out
+
+	err = proj.run(gofmt)
+	if err != nil {
+		LogFail(proj.stderr(), "running gofmt -l failed", "Check")
+		return &StageError{Stage: StageSetup, Err: err}
+	}
+
+	return proj.checkFailed(StageSetup, "files are not formatted, run gojen to format them", strings.Fields(out.String()))
+}
+
+// CheckTidy fails when go mod tidy would change go.mod or go.sum. Tidy runs
+// on copies of both files, so the project is left untouched.
+func (proj *Project) CheckTidy() error {
+	LogInfo(proj.stdout(), "checking go mod tidy", "Check")
+
+	dir, err := ioutil.TempDir("", "gojen-check")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"go.mod", "go.sum"} {
+		err = copyFile(name, filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	tidy := proj.command("go", "mod", "tidy", "-modfile="+filepath.Join(dir, "go.mod"))
+	err = proj.run(tidy)
+	if err != nil {
+		LogFail(proj.stderr(), "running go mod tidy failed", "Check")
+		return &StageError{Stage: StageSetup, Err: err}
+	}
+
+	files := []string{}
+	for _, name := range []string{"go.mod", "go.sum"} {
+		same, err := sameFile(name, filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if !same {
+			files = append(files, name)
+		}
+	}
+
+	return proj.checkFailed(StageSetup, "go.mod and go.sum are not tidy, run gojen to tidy them", files)
+}
+
+// CheckVendor fails when go mod vendor would change the vendor directory.
+// The vendor directory is generated in a temporary directory using go mod
+// vendor -o, which needs go 1.18 or later, see goVendorOutput.
+func (proj *Project) CheckVendor() error {
+	LogInfo(proj.stdout(), "checking go mod vendor", "Check")
+
+	dir, err := ioutil.TempDir("", "gojen-check")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	vendored := filepath.Join(dir, "vendor")
+	vendor := proj.command("go", "mod", "vendor", "-o", vendored)
+	err = proj.run(vendor)
+	if err != nil {
+		LogFail(proj.stderr(), "running go mod vendor failed", "Check")
+		return &StageError{Stage: StageSetup, Err: err}
+	}
+
+	files, err := diffDirs("vendor", vendored)
+	if err != nil {
+		return err
+	}
+
+	return proj.checkFailed(StageSetup, "vendor is out of date, run gojen to vendor the dependencies", files)
+}
+
+// goVendorOutput reports whether the go installed supports go mod vendor -o,
+// which was added in go 1.18. Versions before go 1.16 do not print their
+// GOVERSION, and development versions always support it.
+func goVendorOutput() bool {
+	version := strings.TrimSpace(toolVersion([]string{"go", "env", "GOVERSION"}))
+	if strings.HasPrefix(version, "devel") {
+		return true
+	}
+
+	var major, minor int
+	if _, err := fmt.Sscanf(version, "go%d.%d", &major, &minor); err != nil {
+		return false
+	}
+
+	return major > 1 || minor >= 18
+}
+
+// goFiles returns the go files below dir that go fmt ./... formats, skipping
+// vendor, testdata, nested modules and the directories the go tool ignores.
+func goFiles(dir string) ([]string, error) {
+	files := []string{}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := info.Name()
+		if info.IsDir() {
+			if path != dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); path != dir && err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if strings.HasSuffix(name, ".go") {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+
+	return files, err
+}
+
+// diffDirs returns the files, relative to the working directory, that differ
+// between dir and want, including the ones only found in either of them.
+func diffDirs(dir string, want string) ([]string, error) {
+	have, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted, err := listFiles(want)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for name := range have {
+		if !wanted[name] {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+
+	for name := range wanted {
+		if !have[name] {
+			files = append(files, filepath.Join(dir, name)+" (missing)")
+			continue
+		}
+
+		same, err := sameFile(filepath.Join(dir, name), filepath.Join(want, name))
+		if err != nil {
+			return nil, err
+		}
+		if !same {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// listFiles returns the files below dir relative to it, a missing dir has no
+// files.
+func listFiles(dir string) (map[string]bool, error) {
+	files := map[string]bool{}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files[rel] = true
+		}
+
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return files, nil
+	}
+
+	return files, err
+}
+
+// sameFile reports whether a and b have the same content, a missing file is
+// the same as an empty one.
+func sameFile(a string, b string) (bool, error) {
+	x, err := ioutil.ReadFile(a)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+
+	y, err := ioutil.ReadFile(b)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+
+	return bytes.Equal(x, y), nil
+}
+
+// copyFile copies src to dst, doing nothing when src does not exist.
+func copyFile(src string, dst string) error {
+	b, err := ioutil.ReadFile(src)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(dst, b, 0o644)
+}
@@ -0,0 +1,169 @@
+package project_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	project.Check = true
+	t.Cleanup(func() {
+		project.Check = false
+	})
+
+	files := map[string]string{
+		"go.mod":     "module github.com/test/test\n\ngo 1.17\n",
+		"main.go":    "package main\nfunc main(){ }\n",
+		".gitignore": "vendor\n",
+	}
+	writeFiles(t, dir, files)
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Readme:     project.Bool(false),
+		SkipVendor: project.Bool(true),
+		GoTest:     project.Bool(false),
+		GoBuild:    project.Bool(false),
+	}
+
+	err := p.RunTask(project.DefaultTask)
+	if err == nil {
+		t.Fatal("expected check to fail for an unformatted file and an outdated .gitignore")
+	}
+
+	for name, contents := range files {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != contents {
+			t.Errorf("expected check not to change %s, got:\n%s", name, b)
+		}
+	}
+
+	err = p.CheckTidy()
+	if err != nil {
+		t.Errorf("expected go.mod to be tidy, got %v", err)
+	}
+
+	err = p.CheckModule()
+	if err != nil {
+		t.Errorf("expected go.mod to be found, got %v", err)
+	}
+
+	err = os.Remove(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = p.CheckModule()
+	if err == nil {
+		t.Error("expected check to fail without a go.mod")
+	}
+}
+
+func TestCheckFmt(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	writeFiles(t, dir, map[string]string{
+		"main.go":             "package main\n\nfunc main() {}\n",
+		"vendor/dep/dep.go":   "package dep\nfunc Dep(){ }\n",
+		"testdata/x/x.go":     "package x\nfunc X(){ }\n",
+		"pkg/lib/lib.go":      "package lib\n\nfunc Lib() {}\n",
+		"pkg/lib/unformat.go": "package lib\nfunc Unformatted(){ }\n",
+	})
+
+	p := &project.Project{}
+
+	err := p.CheckFmt()
+	if err == nil {
+		t.Fatal("expected check to fail for pkg/lib/unformat.go")
+	}
+
+	err = os.Remove(filepath.Join(dir, "pkg/lib/unformat.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = p.CheckFmt()
+	if err != nil {
+		t.Errorf("expected files in vendor and testdata to be ignored, got %v", err)
+	}
+}
+
+func TestCheckVendorGoVersion(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module github.com/test/test\n\ngo 1.17\n",
+		"bin/go": "#!/bin/sh\necho go1.17.13\n",
+	})
+	err := os.Chmod(filepath.Join(dir, "bin", "go"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", filepath.Join(dir, "bin")+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	project.Check = true
+	t.Cleanup(func() {
+		project.Check = false
+	})
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+	}
+
+	err = p.RunTask("vendor")
+	if err != nil {
+		t.Fatalf("expected the vendor check to be skipped with go 1.17, got %v", err)
+	}
+
+	for _, r := range p.Results() {
+		if r.Task == "vendor" && (r.Status != project.StatusSkipped || r.Reason != "checking vendor needs go mod vendor -o, added in go 1.18") {
+			t.Errorf("expected vendor to be skipped for the version of go, got %+v", r)
+		}
+	}
+}
+
+func TestFmtThenCheckFmt(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	writeFiles(t, dir, map[string]string{
+		"go.mod":              "module github.com/test/test\n\ngo 1.17\n",
+		"main.go":             "package main\nfunc main(){ }\n",
+		"pkg/lib/unformat.go": "package lib\nfunc Unformatted(){ }\n",
+		"nested/go.mod":       "module github.com/test/nested\n\ngo 1.17\n",
+		"nested/nested.go":    "package nested\nfunc Nested(){ }\n",
+	})
+
+	p := &project.Project{}
+
+	err := p.CheckFmt()
+	if err == nil {
+		t.Fatal("expected check to fail for main.go and pkg/lib/unformat.go")
+	}
+
+	// what go fmt formats is what the check verifies, leaving out the
+	// nested module
+	err = p.RunFmt()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = p.CheckFmt()
+	if err != nil {
+		t.Errorf("expected the files formatted by go fmt to pass the check, got %v", err)
+	}
+}
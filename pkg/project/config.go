@@ -0,0 +1,438 @@
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// ConfigFiles are the config file names gojen looks for, only one of them
+// may exist in a project.
+var ConfigFiles = []string{"gojen.json", "gojen.yaml", "gojen.yml"}
+
+// ErrNoConfig is returned by FindConfig when none of ConfigFiles exist.
+var ErrNoConfig = errors.New("no gojen config found")
+
+// FindConfig returns the path of the config file inside dir.
+func FindConfig(dir string) (string, error) {
+	found := []string{}
+
+	for _, name := range ConfigFiles {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if err == nil {
+			found = append(found, name)
+			continue
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return "", ErrNoConfig
+	case 1:
+		return filepath.Join(dir, found[0]), nil
+	default:
+		return "", fmt.Errorf("found multiple config files (%s), keep only one of them", strings.Join(found, ", "))
+	}
+}
+
+// ConfigFormat returns the format of a config file based on its extension.
+func ConfigFormat(path string) string {
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+func GetConfig() (*Project, error) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	cfgPath, err := FindConfig(pwd)
+	if err != nil {
+		return nil, err
+	}
+
+	proj := &Project{
+		configFile: cfgPath,
+	}
+
+	err = proj.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return proj, nil
+}
+
+// load reads the config file of proj together with the files it extends,
+// keeping the merged document around so ValidateConfig can report unknown
+// keys and type mismatches with their paths.
+func (proj *Project) load() error {
+	doc, err := readConfigNode(proj.configFile)
+	if err != nil {
+		return err
+	}
+
+	return proj.decode(doc)
+}
+
+// decode resets proj to the config in doc, the document of its config file,
+// merged over the files it extends.
+func (proj *Project) decode(doc *yaml.Node) error {
+	*proj = Project{
+		configFile: proj.configFile,
+		doc:        doc,
+	}
+
+	node, origins, err := mergeBases(proj.configFile, copyNode(doc), nil)
+	if err != nil {
+		return err
+	}
+
+	proj.raw = node
+	proj.origins = origins
+
+	err = proj.applyOverrides()
+	if err != nil {
+		return err
+	}
+
+	v := &validator{root: proj.raw, origins: proj.origins, format: ConfigFormat(proj.configFile)}
+	decoded := copyNode(node)
+	v.canonicalKeys(reflect.TypeOf(Project{}), decoded)
+	err = decoded.Decode(proj)
+
+	// values that do not fit their field are skipped by the decoder and
+	// reported by ValidateConfig, anything else is unexpected
+	if err != nil {
+		v.checkNode(reflect.TypeOf(Project{}), proj.raw, "")
+		if len(v.problems) == 0 {
+			return fmt.Errorf("%s: %s", filepath.Base(proj.configFile), err.Error())
+		}
+	}
+
+	// WriteConfig compares against this to find the fields changed on proj
+	proj.decoded = &yaml.Node{}
+
+	return proj.decoded.Encode(proj)
+}
+
+// readConfigNode parses the config file at path into its root node, an empty
+// file results in an empty mapping.
+func readConfigNode(path string) (*yaml.Node, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(path)
+
+	doc := &yaml.Node{}
+	if ConfigFormat(path) == FormatJSON {
+		var generic interface{}
+		err := unmarshalJSONC(b, &generic)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%s", name, err.Error())
+		}
+
+		// JSON is not read as YAML, which it is not quite a subset of,
+		// e.g. \/ is not a YAML escape
+		doc, err = jsonNode(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err.Error())
+		}
+	} else {
+		err = yaml.Unmarshal(b, doc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err.Error())
+		}
+	}
+
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+
+	return doc.Content[0], nil
+}
+
+// SetConfigFormat selects the format WriteConfig uses for a project that was
+// not read from disk.
+func (proj *Project) SetConfigFormat(format string) error {
+	switch format {
+	case FormatJSON:
+		proj.configFile = "gojen.json"
+	case FormatYAML:
+		proj.configFile = "gojen.yaml"
+	default:
+		return fmt.Errorf("unknown config format %q, expected %s or %s", format, FormatJSON, FormatYAML)
+	}
+
+	return nil
+}
+
+// ConfigFile returns the path WriteConfig writes to.
+func (proj *Project) ConfigFile() (string, error) {
+	name := proj.configFile
+	if name == "" {
+		name = "gojen.json"
+	}
+
+	if filepath.IsAbs(name) {
+		return name, nil
+	}
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(pwd, name), nil
+}
+
+// WriteConfig writes the project to its config file. A project that was read
+// from disk writes back the document of its config file, as changed by Set,
+// Unset and Add, so values inherited through extends stay where they are.
+func (proj *Project) WriteConfig() error {
+	cfgPath, err := proj.ConfigFile()
+	if err != nil {
+		return err
+	}
+
+	b, err := proj.MarshalConfig()
+	if err != nil {
+		return err
+	}
+
+	if DryRun {
+		status := FileModified
+		if _, err := os.Stat(cfgPath); errors.Is(err, os.ErrNotExist) {
+			status = FileCreated
+		}
+		printPlan(status, relPath(cfgPath))
+		return nil
+	}
+
+	err = ioutil.WriteFile(cfgPath, b, 0o644)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalConfig returns the contents WriteConfig writes to the config file.
+func (proj *Project) MarshalConfig() ([]byte, error) {
+	cfgPath, err := proj.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if proj.doc == nil && ConfigFormat(cfgPath) == FormatYAML {
+		return proj.marshalYAML(cfgPath)
+	}
+
+	if proj.doc == nil {
+		return json.MarshalIndent(proj, "", "  ")
+	}
+
+	doc, err := proj.updatedDoc()
+	if err != nil {
+		return nil, err
+	}
+
+	if ConfigFormat(cfgPath) == FormatYAML {
+		return encodeYAML(doc)
+	}
+
+	buf := &strings.Builder{}
+	writeJSONNode(buf, doc, "")
+	buf.WriteString("\n")
+
+	return []byte(buf.String()), nil
+}
+
+// updatedDoc returns the document of the config file with the fields that
+// were changed on proj since it was decoded written into it.
+func (proj *Project) updatedDoc() (*yaml.Node, error) {
+	current := &yaml.Node{}
+	err := current.Encode(proj)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := copyNode(proj.doc)
+	for i := 0; i+1 < len(current.Content); i += 2 {
+		key := current.Content[i].Value
+		value := current.Content[i+1]
+
+		changed, err := nodesDiffer(mappingValue(proj.decoded, key), value)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case !changed:
+		case value.Tag == "!!null":
+			removeKey(doc, key)
+		case mappingValue(doc, key) != nil:
+			mergeNode(mappingValue(doc, key), value)
+		default:
+			doc.Content = append(doc.Content, current.Content[i], value)
+		}
+	}
+
+	return doc, nil
+}
+
+func nodesDiffer(a *yaml.Node, b *yaml.Node) (bool, error) {
+	if a == nil || b == nil {
+		return a != b, nil
+	}
+
+	x, err := encodeYAML(a)
+	if err != nil {
+		return false, err
+	}
+
+	y, err := encodeYAML(b)
+	if err != nil {
+		return false, err
+	}
+
+	return string(x) != string(y), nil
+}
+
+// marshalYAML encodes the project as YAML. When cfgPath already exists the
+// new values are merged into the existing document, so comments and key order
+// written by hand survive the rewrite.
+func (proj *Project) marshalYAML(cfgPath string) ([]byte, error) {
+	doc := &yaml.Node{}
+	err := doc.Encode(proj)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(cfgPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if len(b) > 0 {
+		existing := &yaml.Node{}
+		err = yaml.Unmarshal(b, existing)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", filepath.Base(cfgPath), err.Error())
+		}
+
+		if len(existing.Content) > 0 {
+			mergeNode(existing.Content[0], doc)
+			doc = existing
+		}
+	}
+
+	return encodeYAML(doc)
+}
+
+func encodeYAML(node *yaml.Node) ([]byte, error) {
+	buf := &strings.Builder{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+
+	err := enc.Encode(node)
+	if err != nil {
+		return nil, err
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(buf.String()), nil
+}
+
+// mergeNode updates dst in place so that it holds the value of src, while
+// keeping the comments, key order and scalar styles of dst where possible.
+func mergeNode(dst *yaml.Node, src *yaml.Node) {
+	if dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode {
+		content := []*yaml.Node{}
+		seen := map[string]bool{}
+
+		for i := 0; i+1 < len(dst.Content); i += 2 {
+			key := dst.Content[i].Value
+			srcValue := mappingValue(src, key)
+			if srcValue == nil {
+				continue
+			}
+
+			mergeNode(dst.Content[i+1], srcValue)
+			content = append(content, dst.Content[i], dst.Content[i+1])
+			seen[key] = true
+		}
+
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			if !seen[src.Content[i].Value] {
+				content = append(content, src.Content[i], src.Content[i+1])
+			}
+		}
+
+		dst.Content = content
+		return
+	}
+
+	if dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode {
+		for i, item := range src.Content {
+			if i < len(dst.Content) {
+				mergeNode(dst.Content[i], item)
+			} else {
+				dst.Content = append(dst.Content, item)
+			}
+		}
+
+		if len(dst.Content) > len(src.Content) {
+			dst.Content = dst.Content[:len(src.Content)]
+		}
+		return
+	}
+
+	if dst.Kind == yaml.ScalarNode && src.Kind == yaml.ScalarNode &&
+		dst.ShortTag() == src.ShortTag() && dst.Value == src.Value {
+		return
+	}
+
+	dst.Kind = src.Kind
+	dst.Tag = src.Tag
+	dst.Value = src.Value
+	dst.Style = src.Style
+	dst.Content = src.Content
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package project_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		err := os.Chdir(pwd)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestFindConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := project.FindConfig(dir)
+	if err != project.ErrNoConfig {
+		t.Errorf("expected %v, got %v", project.ErrNoConfig, err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "gojen.yml"), []byte("name: test\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfgPath, err := project.FindConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfgPath != filepath.Join(dir, "gojen.yml") {
+		t.Errorf("expected %s, got %s", filepath.Join(dir, "gojen.yml"), cfgPath)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "gojen.json"), []byte("{}"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = project.FindConfig(dir)
+	if err == nil || !strings.Contains(err.Error(), "gojen.json, gojen.yml") {
+		t.Errorf("expected multiple config error, got %v", err)
+	}
+}
+
+func TestYAMLConfig(t *testing.T) {
+	chdir(t, t.TempDir())
+
+	p := project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Gitignore:  project.StringSlice([]string{"dist"}),
+	}
+
+	err := p.SetConfigFormat(project.FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = p.WriteConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proj, err := project.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proj.GetName() != "test" {
+		t.Errorf("expected test, got %s", proj.GetName())
+	}
+
+	if proj.GetRepository() != "github.com/test/test" {
+		t.Errorf("expected github.com/test/test, got %s", proj.GetRepository())
+	}
+
+	if len(proj.GetGitignore()) != 1 || proj.GetGitignore()[0] != "dist" {
+		t.Errorf("expected [dist], got %s", proj.GetGitignore())
+	}
+
+	if err := p.SetConfigFormat("toml"); err == nil {
+		t.Error("expected error for unknown format, got nil")
+	}
+}
+
+func TestYAMLConfigKeepsComments(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	contents := `# project settings
+name: test # the binary name
+repository: github.com/test/test
+gitignore:
+  # build output
+  - dist
+`
+	err := ioutil.WriteFile(filepath.Join(dir, "gojen.yaml"), []byte(contents), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proj, err := project.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proj.Name = project.String("renamed")
+	proj.Gitignore = project.StringSlice([]string{"dist", "coverage.txt"})
+
+	err = proj.WriteConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "gojen.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"# project settings",
+		"name: renamed # the binary name",
+		"# build output",
+		"- coverage.txt",
+	} {
+		if !strings.Contains(string(b), expected) {
+			t.Errorf("expected %q in:\n%s", expected, b)
+		}
+	}
+
+	if strings.Index(string(b), "name:") > strings.Index(string(b), "repository:") {
+		t.Errorf("expected key order to be kept:\n%s", b)
+	}
+}
@@ -0,0 +1,127 @@
+package project
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	colorReset = "\033[0m"
+	colorBold  = "\033[1m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorCyan  = "\033[36m"
+)
+
+// Diff generates the project files in memory, without writing them, and
+// returns the ones that differ from the files on disk, including the files it
+// would remove. Files that are only created when missing, such as README.md,
+// differ only when they are missing.
+func (proj *Project) Diff() ([]*FileChange, error) {
+	proj.inMemory = true
+	defer func() {
+		proj.inMemory = false
+	}()
+
+	start := len(proj.changes)
+	err := proj.Synth()
+	if err != nil {
+		return nil, err
+	}
+
+	changed := []*FileChange{}
+	for _, c := range proj.changes[start:] {
+		if c.Status == FileCreated || c.Status == FileModified || c.Status == FileRemoved {
+			changed = append(changed, c)
+		}
+	}
+
+	return changed, nil
+}
+
+// WriteDiff writes a unified diff of every change to w, colored for a
+// terminal when color is set.
+func WriteDiff(w io.Writer, changes []*FileChange, color bool) error {
+	for _, c := range changes {
+		path := relPath(c.Path)
+
+		diff := difflib.UnifiedDiff{
+			A:        splitLines(c.Old),
+			B:        splitLines(c.New),
+			FromFile: "a/" + path,
+			ToFile:   "b/" + path,
+			Context:  3,
+		}
+		switch c.Status {
+		case FileCreated:
+			diff.FromFile = "/dev/null"
+		case FileRemoved:
+			diff.ToFile = "/dev/null"
+		}
+
+		text, err := difflib.GetUnifiedDiffString(diff)
+		if err != nil {
+			return err
+		}
+
+		if !color {
+			_, err = io.WriteString(w, text)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, line := range strings.SplitAfter(text, "\n") {
+			if line == "" {
+				continue
+			}
+
+			_, err = io.WriteString(w, colorLine(line))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func colorLine(line string) string {
+	text := strings.TrimSuffix(line, "\n")
+
+	var c string
+	switch {
+	case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+		c = colorBold
+	case strings.HasPrefix(line, "@@"):
+		c = colorCyan
+	case strings.HasPrefix(line, "-"):
+		c = colorRed
+	case strings.HasPrefix(line, "+"):
+		c = colorGreen
+	default:
+		return line
+	}
+
+	return fmt.Sprintf("%s%s%s\n", c, text, colorReset)
+}
+
+// splitLines splits b into lines that keep their line endings, as expected
+// by difflib.
+func splitLines(b []byte) []string {
+	if len(b) == 0 {
+		return nil
+	}
+
+	lines := strings.SplitAfter(string(b), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n\\ No newline at end of file\n"
+	return lines
+}
@@ -0,0 +1,117 @@
+package project_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestDiff(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	writeFiles(t, dir, map[string]string{
+		".gitignore":         "vendor\n.idea\ntest\n",
+		"README.md":          "# custom readme\n",
+		"main.go":            "package main\n",
+		".github/CODEOWNERS": "* @old\n",
+	})
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Readme:     project.Bool(true),
+		Gitignore:  project.StringSlice([]string{"vendor", "dist"}),
+		CodeOwners: project.StringSlice([]string{"* @new"}),
+	}
+
+	changes, err := p.Diff()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := []string{}
+	for _, c := range changes {
+		rel, err := filepath.Rel(dir, c.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, rel)
+	}
+	if strings.Join(paths, ",") != ".github/CODEOWNERS,.gitignore" {
+		t.Errorf("expected only CODEOWNERS and .gitignore to differ, got %v", paths)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "vendor\n.idea\ntest\n" {
+		t.Errorf("expected diff not to write .gitignore, got:\n%s", b)
+	}
+
+	var out bytes.Buffer
+	err = project.WriteDiff(&out, changes, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `--- a/.github/CODEOWNERS
++++ b/.github/CODEOWNERS
+@@ -1 +1 @@
+-* @old
++* @new
+\ No newline at end of file
+--- a/.gitignore
++++ b/.gitignore
+@@ -1,3 +1,3 @@
+ vendor
+-.idea
+-test
++dist
++test
+\ No newline at end of file
+`
+	if out.String() != expected {
+		t.Errorf("expected diff:\n%s\ngot:\n%s", expected, out.String())
+	}
+
+	var colored bytes.Buffer
+	err = project.WriteDiff(&colored, changes, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(colored.String(), "\033[32m+dist\033[0m\n") {
+		t.Errorf("expected added lines to be green, got:\n%q", colored.String())
+	}
+}
+
+func TestDiffCreatedFile(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+	}
+
+	changes, err := p.Diff()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	err = project.WriteDiff(&out, changes, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out.String(), "--- /dev/null\n+++ b/main.go\n") {
+		t.Errorf("expected main.go to be diffed as a new file, got:\n%s", out.String())
+	}
+}
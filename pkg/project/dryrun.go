@@ -0,0 +1,159 @@
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DryRun makes gojen report the files it would write and the commands it
+// would run, instead of writing and running them.
+var DryRun bool
+
+// Statuses of a generated file.
+const (
+	FileCreated   = "create"
+	FileModified  = "modify"
+	FileUnchanged = "unchanged"
+	// FileKept is the status of files that are only written when missing,
+	// such as README.md, and already exist.
+	FileKept = "keep"
+	// FileRemoved is the status of files gojen no longer generates, such as
+	// the upload binary workflow of a library.
+	FileRemoved = "remove"
+)
+
+// FileChange is a file written, or in a dry run that would be written, by
+// gojen.
+type FileChange struct {
+	Path   string
+	Status string
+	// Old is the content on disk, New the content gojen generated, which is
+	// nil for removed files.
+	Old []byte
+	New []byte
+}
+
+// Changes returns the files gojen wrote, or would have written in a dry run,
+// in the order they were generated.
+func (proj *Project) Changes() []*FileChange {
+	return proj.changes
+}
+
+// writeFile writes b to path, creating the directories leading up to it. In
+// a dry run the change is only reported.
+func (proj *Project) writeFile(path string, b []byte) error {
+	old, err := ioutil.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	change := &FileChange{Path: path, Status: FileModified, Old: old, New: b}
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		change.Status = FileCreated
+	case bytes.Equal(old, b):
+		change.Status = FileUnchanged
+	}
+	proj.changes = append(proj.changes, change)
+
+	if proj.inMemory {
+		return nil
+	}
+
+	if DryRun {
+		printPlan(change.Status, relPath(path))
+		return nil
+	}
+
+	if change.Status == FileUnchanged {
+		return nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, b, 0o644)
+}
+
+// createFile writes b to path unless the file already exists.
+func (proj *Project) createFile(path string, b []byte) error {
+	old, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return proj.writeFile(path, b)
+	}
+	if err != nil {
+		return err
+	}
+
+	proj.changes = append(proj.changes, &FileChange{Path: path, Status: FileKept, Old: old, New: old})
+	if DryRun && !proj.inMemory {
+		printPlan(FileKept, relPath(path))
+	}
+
+	return nil
+}
+
+// removeFile removes the file at path, which gojen no longer generates. In a
+// dry run the removal is only reported. Nothing is reported when the file
+// does not exist.
+func (proj *Project) removeFile(path string) error {
+	old, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	proj.changes = append(proj.changes, &FileChange{Path: path, Status: FileRemoved, Old: old})
+
+	if proj.inMemory {
+		return nil
+	}
+
+	if DryRun {
+		printPlan(FileRemoved, relPath(path))
+		return nil
+	}
+
+	return os.Remove(path)
+}
+
+func printPlan(action string, subject string) {
+	fmt.Fprintf(TextOutput(), "%-9s %s\n", action, subject)
+}
+
+// formatCommand formats args the way they would be typed in a shell.
+func formatCommand(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$`*?&|;<>(){}[]#~") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted = append(quoted, arg)
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// relPath returns path relative to the working directory, when it is below it.
+func relPath(path string) string {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(pwd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+
+	return rel
+}
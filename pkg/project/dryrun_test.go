@@ -0,0 +1,126 @@
+package project_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestDryRun(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	project.DryRun = true
+	t.Cleanup(func() {
+		project.DryRun = false
+	})
+
+	writeFiles(t, dir, map[string]string{
+		".gitignore": "vendor\n",
+		"README.md":  "# custom readme\n",
+	})
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Readme:     project.Bool(true),
+		License:    project.String("MIT"),
+		Gitignore:  project.StringSlice([]string{"vendor"}),
+		Tasks: &map[string]*project.TaskConfig{
+			"touch": {
+				Steps: project.StringSlice([]string{"touch touched"}),
+			},
+		},
+	}
+
+	err := p.Synth()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = p.RunTask("touch")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := map[string]string{}
+	for _, c := range p.Changes() {
+		rel, err := filepath.Rel(dir, c.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		statuses[rel] = c.Status
+	}
+
+	expected := map[string]string{
+		"LICENSE":    project.FileCreated,
+		"README.md":  project.FileKept,
+		".gitignore": project.FileModified,
+		"main.go":    project.FileCreated,
+	}
+	for path, status := range expected {
+		if statuses[path] != status {
+			t.Errorf("expected %s to be reported as %q, got %q", path, status, statuses[path])
+		}
+	}
+
+	for _, path := range []string{"LICENSE", "main.go", "touched"} {
+		if _, err := os.Stat(filepath.Join(dir, path)); err == nil {
+			t.Errorf("expected %s not to be written in a dry run", path)
+		}
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "vendor\n" {
+		t.Errorf("expected .gitignore to be left as is, got:\n%s", b)
+	}
+}
+
+func TestDryRunJSON(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	var events bytes.Buffer
+	project.DryRun = true
+	project.Output, project.Events = project.OutputJSON, &events
+	t.Cleanup(func() {
+		project.DryRun = false
+		project.Output, project.Events = project.OutputText, os.Stdout
+	})
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Tasks: &map[string]*project.TaskConfig{
+			"touch": {
+				Steps: project.StringSlice([]string{"touch touched"}),
+			},
+		},
+	}
+
+	// stdout holds nothing but the events, the plan goes to stderr
+	stdout, stderr := captureOutput(t, func() {
+		err := p.RunTask("touch")
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	if stdout != "" {
+		t.Errorf("expected nothing on stdout with JSON output, got %q", stdout)
+	}
+	if !strings.Contains(stderr, "run       sh -c 'touch touched'") {
+		t.Errorf("expected the plan on stderr, got %q", stderr)
+	}
+	if !strings.Contains(events.String(), `"event":"stageStarted"`) {
+		t.Errorf("expected the events of touch, got:\n%s", events.String())
+	}
+}
@@ -0,0 +1,255 @@
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Get returns the effective value at path, e.g. workflowEnv.FOO, falling back
+// to the default of fields that are not configured. Lists and objects are
+// returned as JSON.
+func (proj *Project) Get(path string) (string, error) {
+	n := lookupNode(proj.raw, path)
+	if n == nil && len(splitPath(path)) > 0 && mappingValue(proj.raw, splitPath(path)[0].key) == nil {
+		defaults := &yaml.Node{}
+		err := defaults.Encode(PresetDefaults(proj.GetPreset()))
+		if err != nil {
+			return "", err
+		}
+
+		n = lookupNode(defaults, path)
+	}
+
+	if n == nil {
+		return "", fmt.Errorf("%s is not set", path)
+	}
+
+	if n.Kind == yaml.ScalarNode && n.Tag != "!!null" {
+		return n.Value, nil
+	}
+
+	b := &strings.Builder{}
+	writeJSONNode(b, n, "")
+
+	return b.String(), nil
+}
+
+// Set sets the value at path in the config file, creating the objects leading
+// up to it. The value is parsed as YAML unless the field is a string.
+func (proj *Project) Set(path string, value string) error {
+	node, err := parseValueFor(fieldType(path), value)
+	if err != nil {
+		return fmt.Errorf("%s: %s", path, err.Error())
+	}
+
+	doc := copyNode(proj.doc)
+	err = setNode(doc, path, node)
+	if err != nil {
+		return err
+	}
+
+	return proj.decode(doc)
+}
+
+// Unset removes the value at path from the config file. Values inherited
+// from the files it extends cannot be unset.
+func (proj *Project) Unset(path string) error {
+	doc := copyNode(proj.doc)
+
+	parts := splitPath(path)
+	if len(parts) == 0 {
+		return errors.New("empty key")
+	}
+
+	parent := lookupNode(doc, joinParts(parts[:len(parts)-1]))
+	last := parts[len(parts)-1]
+
+	switch {
+	case parent == nil:
+	case last.index < 0 && parent.Kind == yaml.MappingNode && mappingValue(parent, last.key) != nil:
+		removeKey(parent, last.key)
+		return proj.decode(doc)
+	case last.index >= 0 && parent.Kind == yaml.SequenceNode && last.index < len(parent.Content):
+		parent.Content = append(parent.Content[:last.index], parent.Content[last.index+1:]...)
+		return proj.decode(doc)
+	}
+
+	if lookupNode(proj.raw, path) != nil {
+		return fmt.Errorf("%s is inherited from %s, it can only be unset there", path, proj.fileOf(path))
+	}
+
+	return fmt.Errorf("%s is not set", path)
+}
+
+// Add appends value to the list at path in the config file, unless the list
+// already contains it.
+func (proj *Project) Add(path string, value string) error {
+	t := fieldType(path)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t != nil && t.Kind() != reflect.Slice {
+		return fmt.Errorf("%s is not a list", path)
+	}
+
+	var elem reflect.Type
+	if t != nil {
+		elem = t.Elem()
+	}
+
+	node, err := parseValueFor(elem, value)
+	if err != nil {
+		return fmt.Errorf("%s: %s", path, err.Error())
+	}
+
+	doc := copyNode(proj.doc)
+
+	list := lookupNode(doc, path)
+	switch {
+	case list == nil || list.Tag == "!!null":
+		err = setNode(doc, path, &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{node}})
+		if err != nil {
+			return err
+		}
+
+		return proj.decode(doc)
+	case list.Kind != yaml.SequenceNode:
+		return fmt.Errorf("%s is not a list", path)
+	case node.Kind == yaml.ScalarNode && containsScalar(list, node.Value):
+		return nil
+	}
+
+	list.Content = append(list.Content, node)
+
+	return proj.decode(doc)
+}
+
+// fieldType returns the type of the Project field, or the part of it, at
+// path. It returns nil for paths that are not part of the config.
+func fieldType(path string) reflect.Type {
+	t := reflect.TypeOf(Project{})
+
+	for _, part := range splitPath(path) {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		switch {
+		case part.index >= 0 && t.Kind() == reflect.Slice:
+			t = t.Elem()
+		case part.index < 0 && t.Kind() == reflect.Map:
+			t = t.Elem()
+		case part.index < 0 && t.Kind() == reflect.Struct:
+			found := false
+			for i := 0; i < t.NumField(); i++ {
+				if name, _ := fieldKey(t.Field(i), FormatJSON); name == part.key && t.Field(i).PkgPath == "" {
+					t = t.Field(i).Type
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				return nil
+			}
+		default:
+			return nil
+		}
+	}
+
+	return t
+}
+
+// parseValueFor parses a value given on the command line for a field of type
+// t, values of string fields are taken as is.
+func parseValueFor(t reflect.Type, s string) (*yaml.Node, error) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t != nil && t.Kind() == reflect.String {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}, nil
+	}
+
+	return parseValue(s)
+}
+
+func copyNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	c := *node
+	c.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		c.Content[i] = copyNode(child)
+	}
+
+	return &c
+}
+
+// writeJSONNode writes node as indented JSON, keeping the order of its keys.
+func writeJSONNode(b *strings.Builder, node *yaml.Node, indent string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			b.WriteString("{}")
+			return
+		}
+
+		b.WriteString("{\n")
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, _ := json.Marshal(node.Content[i].Value)
+			fmt.Fprintf(b, "%s  %s: ", indent, key)
+			writeJSONNode(b, node.Content[i+1], indent+"  ")
+			if i+2 < len(node.Content) {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "}")
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			b.WriteString("[]")
+			return
+		}
+
+		b.WriteString("[\n")
+		for i, item := range node.Content {
+			b.WriteString(indent + "  ")
+			writeJSONNode(b, item, indent+"  ")
+			if i+1 < len(node.Content) {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "]")
+	case yaml.AliasNode:
+		writeJSONNode(b, node.Alias, indent)
+	default:
+		switch node.ShortTag() {
+		case "!!null":
+			b.WriteString("null")
+		case "!!bool", "!!int", "!!float":
+			var v interface{}
+			if err := node.Decode(&v); err == nil {
+				if out, err := json.Marshal(v); err == nil {
+					b.Write(out)
+					return
+				}
+			}
+
+			value, _ := json.Marshal(node.Value)
+			b.Write(value)
+		default:
+			value, _ := json.Marshal(node.Value)
+			b.Write(value)
+		}
+	}
+}
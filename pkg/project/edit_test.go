@@ -0,0 +1,143 @@
+package project_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestEditConfig(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	writeFiles(t, dir, map[string]string{
+		"base.yaml": "authorOrganization: acme\ngitignore: [.idea]\n",
+		"gojen.json": `{
+  "extends": ["base.yaml"],
+  "name": "test",
+  "repository": "github.com/test/test",
+  "gitignore": ["dist"],
+  "workflowEnv": {"FOO": "foo"}
+}`,
+	})
+
+	proj, err := project.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, edit := range []func() error{
+		func() error { return proj.Set("goTest", "false") },
+		func() error { return proj.Set("goVersion", "1.17") },
+		func() error { return proj.Set("workflowEnv.BAR", "bar") },
+		func() error { return proj.Unset("workflowEnv.FOO") },
+		func() error { return proj.Add("gitignore", "coverage.txt") },
+		func() error { return proj.Add("gitignore", "dist") },
+		func() error { return proj.Add("codeOwners", "* @test") },
+	} {
+		err = edit()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = proj.ValidateConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proj.IsGoTest() || proj.GetGoVersion() != "1.17" {
+		t.Errorf("expected edits to be decoded, got goTest %v and goVersion %s", proj.IsGoTest(), proj.GetGoVersion())
+	}
+
+	err = proj.WriteConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "gojen.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{
+  "extends": [
+    "base.yaml"
+  ],
+  "name": "test",
+  "repository": "github.com/test/test",
+  "gitignore": [
+    "dist",
+    "coverage.txt"
+  ],
+  "workflowEnv": {
+    "BAR": "bar"
+  },
+  "goTest": false,
+  "goVersion": "1.17",
+  "codeOwners": [
+    "* @test"
+  ]
+}
+`
+	if string(b) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b)
+	}
+
+	value, err := proj.Get("gitignore[0]")
+	if err != nil || value != ".idea" {
+		t.Errorf("expected inherited .idea, got %q (%v)", value, err)
+	}
+
+	value, err = proj.Get("gojenVersion")
+	if err != nil || value != "latest" {
+		t.Errorf("expected default latest, got %q (%v)", value, err)
+	}
+
+	err = proj.Unset("authorOrganization")
+	if err == nil || !strings.Contains(err.Error(), "inherited") {
+		t.Errorf("expected inherited values to not be unset, got %v", err)
+	}
+
+	err = proj.Add("name", "x")
+	if err == nil {
+		t.Error("expected add to a string to fail")
+	}
+}
+
+func TestEditYAMLConfig(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	writeFiles(t, dir, map[string]string{
+		"gojen.yaml": "# project settings\nname: test # the binary name\nrepository: github.com/test/test\n",
+	})
+
+	proj, err := project.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = proj.Set("goVersion", "1.17")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = proj.WriteConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "gojen.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "# project settings\nname: test # the binary name\nrepository: github.com/test/test\ngoVersion: \"1.17\"\n"
+	if string(b) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b)
+	}
+}
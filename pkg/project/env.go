@@ -0,0 +1,182 @@
+package project
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// testEnv returns the KEY=value pairs go test runs with: the variables of
+// the testEnvFile that are not set in the environment already, followed by
+// the testEnvVars with a value. The testEnvFile is skipped when it does not
+// exist, as it is not committed and so missing in the workflows.
+func (proj *Project) testEnv() ([]string, error) {
+	env := []string{}
+
+	if file := proj.GetTestEnvFile(); file != "" {
+		vars, err := parseEnvFile(file)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		for _, v := range vars {
+			key := strings.SplitN(v, "=", 2)[0]
+			if _, ok := os.LookupEnv(key); !ok {
+				env = append(env, v)
+			}
+		}
+	}
+
+	for _, v := range proj.GetTestEnvVars() {
+		if strings.Contains(v, "=") {
+			env = append(env, v)
+		}
+	}
+
+	return env, nil
+}
+
+// testEnvInputs returns the value go test gets for every variable of the
+// testEnvFile and testEnvVars, those it inherits from the environment of
+// gojen included, so the cache of test notices when any of them changes.
+func (proj *Project) testEnvInputs() ([]string, error) {
+	env, err := proj.testEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	if file := proj.GetTestEnvFile(); file != "" {
+		// testEnv read the file already, so it is valid when it exists
+		vars, _ := parseEnvFile(file)
+		for _, v := range vars {
+			keys = append(keys, strings.SplitN(v, "=", 2)[0])
+		}
+	}
+	for _, v := range proj.GetTestEnvVars() {
+		keys = append(keys, strings.SplitN(v, "=", 2)[0])
+	}
+
+	values := map[string]string{}
+	for _, key := range keys {
+		if value, ok := os.LookupEnv(key); ok {
+			values[key] = "=" + value
+		} else {
+			values[key] = " is not set"
+		}
+	}
+	for _, v := range env {
+		kv := strings.SplitN(v, "=", 2)
+		values[kv[0]] = "=" + kv[1]
+	}
+
+	inputs := []string{}
+	for key, value := range values {
+		inputs = append(inputs, key+value)
+	}
+	sort.Strings(inputs)
+
+	return inputs, nil
+}
+
+// workflowEnv returns the environment of the workflow step running gojen:
+// the workflowEnv along with the testEnvVars, where a variable without a
+// value is set from the repository secret of the same name.
+func (proj *Project) workflowEnv() *map[string]*string {
+	if len(proj.GetTestEnvVars()) == 0 {
+		return proj.GetWorkflowEnv()
+	}
+
+	env := map[string]*string{}
+	for k, v := range *proj.GetWorkflowEnv() {
+		env[k] = v
+	}
+
+	for _, v := range proj.GetTestEnvVars() {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) == 2 {
+			env[kv[0]] = String(kv[1])
+		} else {
+			env[kv[0]] = String(fmt.Sprintf("${{ secrets.%s }}", kv[0]))
+		}
+	}
+
+	return &env
+}
+
+// parseEnvFile reads the KEY=value pairs of a dotenv file. Blank lines and
+// lines starting with # are skipped, as is an export before the key. Values
+// can be quoted, within double quotes \n, \" and \\ are unescaped, and an
+// unquoted value ends at a # preceded by a space.
+func parseEnvFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	env := []string{}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		kv := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || !envVarNameRe.MatchString(key) {
+			return nil, fmt.Errorf("%s:%d: expected KEY=value", path, n)
+		}
+
+		value, err := envValue(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", path, n, err.Error())
+		}
+
+		env = append(env, key+"="+value)
+	}
+
+	return env, scanner.Err()
+}
+
+// envValue returns the value of a line of a dotenv file, unquoted.
+func envValue(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+
+	quote := s[0]
+	if quote != '"' && quote != '\'' {
+		if i := strings.Index(s, " #"); i >= 0 {
+			s = s[:i]
+		}
+		return strings.TrimSpace(s), nil
+	}
+
+	end := strings.LastIndexByte(s, quote)
+	if end == 0 {
+		return "", fmt.Errorf("missing closing %c", quote)
+	}
+
+	value := s[1:end]
+	if quote == '"' {
+		value = strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(value)
+	}
+
+	return value, nil
+}
+
+// checkTestEnv checks the names of the testEnvVars.
+func (v *validator) checkTestEnv(proj *Project) {
+	for i, e := range proj.GetTestEnvVars() {
+		key := strings.SplitN(e, "=", 2)[0]
+		if !envVarNameRe.MatchString(key) {
+			v.add(fmt.Sprintf("testEnvVars[%d]", i), fmt.Sprintf("%q is not a valid environment variable name, expected KEY or KEY=value", key))
+		}
+	}
+}
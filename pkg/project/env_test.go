@@ -0,0 +1,121 @@
+package project_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestTestEnv(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".env": `# local credentials
+export DB_USER=gojen
+DB_PASSWORD="p\"ss # word"
+DB_HOST='localhost' # a comment
+DB_NAME=test # a comment
+
+ALREADY_SET=file
+`,
+	})
+	chdir(t, dir)
+	t.Setenv("ALREADY_SET", "environment")
+
+	p := &project.Project{
+		Name:        project.String("test"),
+		Repository:  project.String("github.com/test/test"),
+		TestEnvVars: project.StringSlice([]string{"INTEGRATION=true", "API_TOKEN"}),
+		TestEnvFile: project.String(".env"),
+	}
+
+	runner := &project.RecordingRunner{}
+	p.SetRunner(runner)
+
+	err := p.RunTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ALREADY_SET is set in the environment already, so the file does not
+	// override it
+	expected := []string{
+		"DB_USER=gojen",
+		`DB_PASSWORD=p"ss # word`,
+		"DB_HOST=localhost",
+		"DB_NAME=test",
+		"INTEGRATION=true",
+	}
+	if got := runner.Commands[0].Env; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected go test to run with %q, got %q", expected, got)
+	}
+
+	err = p.CreateBuildWorkflow()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(".github", "workflows", "build.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, env := range []string{`INTEGRATION: "true"`, "API_TOKEN: ${{ secrets.API_TOKEN }}"} {
+		if !strings.Contains(string(b), env) {
+			t.Errorf("expected %s in the environment of the workflow, got:\n%s", env, b)
+		}
+	}
+
+	err = p.SetGitignore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err = ioutil.ReadFile(".gitignore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), ".env\n") {
+		t.Errorf("expected the env file to be ignored by git, got:\n%s", b)
+	}
+
+	// the file is not committed, so it is missing in the workflows
+	p.TestEnvFile = project.String("missing.env")
+	runner.Commands = nil
+	err = p.RunTest()
+	if err != nil {
+		t.Fatalf("expected a missing env file to be skipped, got %v", err)
+	}
+
+	writeFiles(t, dir, map[string]string{"bad.env": "DB_USER gojen\n"})
+	p.TestEnvFile = project.String("bad.env")
+	err = p.RunTest()
+
+	var stageErr *project.StageError
+	if !errors.As(err, &stageErr) || !strings.Contains(err.Error(), "bad.env:1: expected KEY=value") {
+		t.Errorf("expected the line of the env file that is invalid, got %v", err)
+	}
+}
+
+func TestValidateTestEnvVars(t *testing.T) {
+	p := &project.Project{
+		Name:        project.String("test"),
+		Repository:  project.String("github.com/test/test"),
+		TestEnvVars: project.StringSlice([]string{"GOOD=1", "ALSO_GOOD", "NOT GOOD=1"}),
+	}
+
+	err := p.ValidateConfig()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !strings.Contains(err.Error(), `testEnvVars[2]: "NOT GOOD" is not a valid environment variable name`) {
+		t.Errorf("expected the invalid name, got:\n%s", err)
+	}
+	if strings.Contains(err.Error(), "testEnvVars[0]") || strings.Contains(err.Error(), "testEnvVars[1]") {
+		t.Errorf("expected the other names to be valid, got:\n%s", err)
+	}
+}
@@ -0,0 +1,99 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Stages of the pipeline a failure is reported for.
+const (
+	StageGenerate = "generate"
+	StageSetup    = "setup"
+	StageLint     = "lint"
+	StageTest     = "test"
+	StageBuild    = "build"
+	// StageTask is the stage of the tasks defined in the config.
+	StageTask = "task"
+)
+
+// Exit codes of gojen. Every stage has its own, so wrappers can tell a lint
+// failure from a test failure.
+const (
+	// ExitError is used for an invalid config and any error that is not
+	// the failure of a stage.
+	ExitError    = 1
+	ExitGenerate = 2
+	ExitSetup    = 3
+	ExitLint     = 4
+	ExitTest     = 5
+	ExitBuild    = 6
+	ExitTask     = 7
+)
+
+var exitCodes = map[string]int{
+	StageGenerate: ExitGenerate,
+	StageSetup:    ExitSetup,
+	StageLint:     ExitLint,
+	StageTest:     ExitTest,
+	StageBuild:    ExitBuild,
+	StageTask:     ExitTask,
+}
+
+// StageError is returned when a stage of the pipeline fails, after the
+// failure was logged. Err is the underlying error, an *exec.ExitError when a
+// command failed.
+type StageError struct {
+	Stage string
+	// Task is the task that failed, when the stage ran as part of one.
+	Task string
+	Err  error
+}
+
+func (e *StageError) Error() string {
+	if e.Task == "" {
+		return fmt.Sprintf("%s stage failed: %s", e.Stage, e.Err)
+	}
+
+	return fmt.Sprintf("%s stage failed in task %s: %s", e.Stage, e.Task, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code of the stage.
+func (e *StageError) ExitCode() int {
+	if code, ok := exitCodes[e.Stage]; ok {
+		return code
+	}
+
+	return ExitError
+}
+
+// ExitCode returns the exit code gojen exits with for err.
+func ExitCode(err error) int {
+	var stageErr *StageError
+	if errors.As(err, &stageErr) {
+		return stageErr.ExitCode()
+	}
+
+	return ExitError
+}
+
+// stageError wraps err in a StageError for stage and task, unless it is one
+// already, in which case only the missing task is filled in.
+func stageError(stage string, task string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var stageErr *StageError
+	if errors.As(err, &stageErr) {
+		if stageErr.Task == "" {
+			stageErr.Task = task
+		}
+		return err
+	}
+
+	return &StageError{Stage: stage, Task: task, Err: err}
+}
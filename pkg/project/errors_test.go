@@ -0,0 +1,63 @@
+package project_test
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestStageError(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Tasks: &map[string]*project.TaskConfig{
+			"fail": {
+				Steps: project.StringSlice([]string{"exit 3"}),
+			},
+		},
+	}
+
+	err := p.RunTask("fail")
+
+	var stageErr *project.StageError
+	if !errors.As(err, &stageErr) {
+		t.Fatalf("expected a StageError, got %v", err)
+	}
+	if stageErr.Stage != project.StageTask || stageErr.Task != "fail" {
+		t.Errorf("expected the task stage of fail to fail, got %s", stageErr)
+	}
+	if project.ExitCode(err) != project.ExitTask {
+		t.Errorf("expected exit code %d, got %d", project.ExitTask, project.ExitCode(err))
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("expected the error to wrap the exit status of the step, got %v", err)
+	}
+
+	p.SetRunner(&project.RecordingRunner{
+		Err: func(c *project.Command) error {
+			if c.Name == "go" && c.Args[0] == "test" {
+				return errors.New("exit status 1")
+			}
+			return nil
+		},
+	})
+
+	err = p.RunTask("test")
+	if !errors.As(err, &stageErr) || stageErr.Stage != project.StageTest || stageErr.Task != "test" {
+		t.Errorf("expected the test stage to fail, got %v", err)
+	}
+	if project.ExitCode(err) != project.ExitTest {
+		t.Errorf("expected exit code %d, got %d", project.ExitTest, project.ExitCode(err))
+	}
+
+	if project.ExitCode(errors.New("invalid config")) != project.ExitError {
+		t.Error("expected other errors to exit with ExitError")
+	}
+}
@@ -0,0 +1,149 @@
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Formats gojen reports its progress in.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// Output is the format gojen reports its progress in. With OutputJSON the
+// log lines are replaced by Events, written to Events one JSON object per
+// line, and the output of every task is captured to a file.
+var Output = OutputText
+
+// Events is where the events are written with OutputJSON.
+var Events io.Writer = os.Stdout
+
+// eventsMu serializes the events of stages running in parallel.
+var eventsMu sync.Mutex
+
+// Types of events.
+const (
+	EventStageStarted  = "stageStarted"
+	EventStageFinished = "stageFinished"
+	EventStageSkipped  = "stageSkipped"
+	EventCommand       = "command"
+)
+
+// Statuses of a stage.
+const (
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// Event is written for every task that starts, finishes or is skipped, and
+// every command run, with OutputJSON.
+type Event struct {
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+	Task  string    `json:"task"`
+	Stage string    `json:"stage,omitempty"`
+	// Command and Dir are set for EventCommand.
+	Command string `json:"command,omitempty"`
+	Dir     string `json:"dir,omitempty"`
+	// Reason is set for EventStageSkipped.
+	Reason string `json:"reason,omitempty"`
+	// Status, Duration and Output are set for EventStageFinished, along
+	// with ExitCode and Error when the stage failed.
+	Status     string `json:"status,omitempty"`
+	DurationMs *int64 `json:"durationMs,omitempty"`
+	// ExitCode is the exit code of the command that failed, if any.
+	ExitCode *int   `json:"exitCode,omitempty"`
+	Error    string `json:"error,omitempty"`
+	// Output is the file the output of the task was captured to.
+	Output string `json:"output,omitempty"`
+}
+
+// TextOutput returns where gojen writes the messages that are not log lines
+// of a stage, such as errors or the plan of a dry run: os.Stdout, or
+// os.Stderr with OutputJSON, so stdout only holds the events.
+func TextOutput() io.Writer {
+	return (&Project{}).textOut()
+}
+
+// textOut returns where proj writes the messages that are not log lines of a
+// stage, see TextOutput.
+func (proj *Project) textOut() io.Writer {
+	if Output == OutputJSON {
+		return proj.stderr()
+	}
+
+	return proj.stdout()
+}
+
+func (proj *Project) emit(e *Event) {
+	if Output != OutputJSON {
+		return
+	}
+
+	e.Time = time.Now().UTC()
+	if e.Task == "" {
+		e.Task = proj.task
+	}
+
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+
+	// an event that cannot be written must not fail the run
+	_ = json.NewEncoder(Events).Encode(e)
+}
+
+// startCapture sends the output of the project to a file for t in the
+// output directory of the run with OutputJSON, returning the path of the file and a function that stops it.
+func (proj *Project) startCapture(t *Task) (string, func(), error) {
+	if Output != OutputJSON {
+		return "", func() {}, nil
+	}
+
+	path := filepath.Join(proj.outputDir, t.Name+".log")
+	f, err := os.Create(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	proj.out, proj.errOut = f, f
+
+	return path, func() {
+		proj.out, proj.errOut = nil, nil
+		f.Close()
+	}, nil
+}
+
+// finishedEvent returns the EventStageFinished of t, which ran for d and
+// returned err.
+func finishedEvent(t *Task, d time.Duration, err error, output string) *Event {
+	ms := d.Milliseconds()
+	e := &Event{
+		Event:      EventStageFinished,
+		Task:       t.Name,
+		Stage:      t.Stage,
+		Status:     StatusPassed,
+		DurationMs: &ms,
+		Output:     output,
+	}
+
+	if err != nil {
+		e.Status = StatusFailed
+		e.Error = err.Error()
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			code := exitErr.ExitCode()
+			e.ExitCode = &code
+		}
+	}
+
+	return e
+}
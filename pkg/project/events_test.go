@@ -0,0 +1,108 @@
+package project_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestEvents(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	var events bytes.Buffer
+	project.Output = project.OutputJSON
+	project.Events = &events
+	t.Cleanup(func() {
+		project.Output = project.OutputText
+		project.Events = os.Stdout
+	})
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		GoLinter:   project.Bool(false),
+		Tasks: &map[string]*project.TaskConfig{
+			"greet": {
+				DependsOn: project.StringSlice([]string{"lint"}),
+				Steps:     project.StringSlice([]string{"echo hello", "exit 3"}),
+			},
+		},
+	}
+
+	// only the steps of greet run, the commands of the built-in tasks are
+	// recorded
+	p.SetRunner(&project.RecordingRunner{
+		Err: func(c *project.Command) error {
+			if c.Name == "sh" {
+				return project.ExecRunner{}.Run(c)
+			}
+			return nil
+		},
+	})
+
+	err := p.RunTask("greet")
+	if err == nil {
+		t.Fatal("expected greet to fail")
+	}
+
+	got := []*project.Event{}
+	for _, line := range strings.Split(strings.TrimSpace(events.String()), "\n") {
+		e := &project.Event{}
+		err := json.Unmarshal([]byte(line), e)
+		if err != nil {
+			t.Fatalf("expected every line to be a JSON event, got %q: %v", line, err)
+		}
+		if e.Task == "lint" || e.Task == "greet" {
+			got = append(got, e)
+		}
+	}
+
+	types := []string{}
+	for _, e := range got {
+		types = append(types, e.Event+" "+e.Task)
+	}
+
+	expected := []string{
+		"stageSkipped lint",
+		"stageStarted greet",
+		"command greet",
+		"command greet",
+		"stageFinished greet",
+	}
+	if strings.Join(types, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected events %q, got %q", expected, types)
+	}
+
+	if got[0].Reason != "goLinter is not set" {
+		t.Errorf("expected the reason lint was skipped, got %q", got[0].Reason)
+	}
+
+	if got[2].Command != "sh -c 'echo hello'" || got[2].Dir == "" {
+		t.Errorf("expected the command and its directory, got %+v", got[2])
+	}
+
+	finished := got[4]
+	if finished.Status != project.StatusFailed || finished.Stage != project.StageTask {
+		t.Errorf("expected greet to fail in the task stage, got %+v", finished)
+	}
+	if finished.ExitCode == nil || *finished.ExitCode != 3 {
+		t.Errorf("expected the exit code of the failing step, got %v", finished.ExitCode)
+	}
+	if finished.DurationMs == nil {
+		t.Error("expected the duration of greet")
+	}
+
+	b, err := ioutil.ReadFile(finished.Output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "hello\n") {
+		t.Errorf("expected the output of greet to be captured, got:\n%s", b)
+	}
+}
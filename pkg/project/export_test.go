@@ -0,0 +1,32 @@
+package project
+
+import "time"
+
+// SetWatchTicks makes Watch poll once for every value sent on ticks, and
+// send on waiting whenever it waits for the next one, until restore is
+// called.
+func SetWatchTicks(ticks <-chan time.Time, waiting chan<- struct{}) (restore func()) {
+	prevTicks, prevWaiting := watchTicks, watchWaiting
+
+	watchTicks = func() (<-chan time.Time, func()) {
+		return ticks, func() {}
+	}
+	watchWaiting = func() {
+		waiting <- struct{}{}
+	}
+
+	return func() {
+		watchTicks, watchWaiting = prevTicks, prevWaiting
+	}
+}
+
+// SetKillGracePeriod sets how long commands that must stop have after
+// SIGTERM, until restore is called.
+func SetKillGracePeriod(d time.Duration) (restore func()) {
+	prev := killGracePeriod
+	killGracePeriod = d
+
+	return func() {
+		killGracePeriod = prev
+	}
+}
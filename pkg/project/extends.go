@@ -0,0 +1,234 @@
+package project
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadConfig reads the config file at path and merges it over the files
+// listed in its extends key. It returns the merged document together with
+// the file every value in it came from, keyed by path.
+//
+// Bases are merged in the order they are listed, and the file itself last.
+// Objects are merged key by key and scalars override. Lists are appended,
+// skipping values that are already present, unless their field is tagged
+// with merge:"replace", in which case the later list replaces the earlier.
+func loadConfig(path string, chain []string) (*yaml.Node, map[string]string, error) {
+	for _, p := range chain {
+		if p == path {
+			return nil, nil, fmt.Errorf("extends cycle: %s", strings.Join(append(chain, path), " -> "))
+		}
+	}
+
+	node, err := readConfigNode(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mergeBases(path, node, chain)
+}
+
+// mergeBases merges node, the document of the config file at path, over the
+// files listed in its extends key.
+func mergeBases(path string, node *yaml.Node, chain []string) (*yaml.Node, map[string]string, error) {
+	origins := map[string]string{}
+	recordOrigins(node, "", path, origins)
+
+	extends := mappingValue(node, "extends")
+	if extends == nil || extends.Kind != yaml.SequenceNode || len(extends.Content) == 0 {
+		return node, origins, nil
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	mergedOrigins := map[string]string{}
+
+	for _, item := range extends.Content {
+		if item.Kind != yaml.ScalarNode || item.Value == "" {
+			continue
+		}
+
+		basePath := item.Value
+		if !filepath.IsAbs(basePath) {
+			basePath = filepath.Join(filepath.Dir(path), basePath)
+		}
+
+		base, baseOrigins, err := loadConfig(basePath, append(chain, path))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: extends %s: %s", filepath.Base(path), item.Value, err.Error())
+		}
+
+		removeKey(base, "extends")
+		overlay(merged, base, "", baseOrigins, mergedOrigins)
+	}
+
+	overlay(merged, node, "", origins, mergedOrigins)
+
+	return merged, mergedOrigins, nil
+}
+
+// overlay merges src over dst in place, moving the origins of every value it
+// takes from src over to origins.
+func overlay(dst *yaml.Node, src *yaml.Node, path string, srcOrigins map[string]string, origins map[string]string) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key := src.Content[i].Value
+		value := src.Content[i+1]
+		keyPath := joinPath(path, key)
+
+		existing := mappingValue(dst, key)
+		switch {
+		case existing == nil:
+			dst.Content = append(dst.Content, src.Content[i], value)
+			moveOrigins(keyPath, keyPath, srcOrigins, origins)
+		case existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			origins[keyPath] = srcOrigins[keyPath]
+			overlay(existing, value, keyPath, srcOrigins, origins)
+		case existing.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode && path == "" && mergeRule(key) != "replace":
+			origins[keyPath] = srcOrigins[keyPath]
+			for j, item := range value.Content {
+				if item.Kind == yaml.ScalarNode && containsScalar(existing, item.Value) {
+					continue
+				}
+
+				moveOrigins(fmt.Sprintf("%s[%d]", keyPath, j), fmt.Sprintf("%s[%d]", keyPath, len(existing.Content)), srcOrigins, origins)
+				existing.Content = append(existing.Content, item)
+			}
+		default:
+			*existing = *value
+			clearOrigins(keyPath, origins)
+			moveOrigins(keyPath, keyPath, srcOrigins, origins)
+		}
+	}
+}
+
+// mergeRule returns the merge tag of the Project field stored under key.
+func mergeRule(key string) string {
+	t := reflect.TypeOf(Project{})
+	for i := 0; i < t.NumField(); i++ {
+		if name, _ := fieldKey(t.Field(i), FormatJSON); name == key {
+			return t.Field(i).Tag.Get("merge")
+		}
+	}
+
+	return ""
+}
+
+func recordOrigins(node *yaml.Node, path string, file string, origins map[string]string) {
+	origins[path] = file
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			recordOrigins(node.Content[i+1], joinPath(path, node.Content[i].Value), file, origins)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			recordOrigins(item, fmt.Sprintf("%s[%d]", path, i), file, origins)
+		}
+	}
+}
+
+// moveOrigins copies the origins of from and everything below it in src to
+// the same places below to in dst.
+func moveOrigins(from string, to string, src map[string]string, dst map[string]string) {
+	for p, file := range src {
+		if rest, ok := underPath(p, from); ok {
+			dst[to+rest] = file
+		}
+	}
+}
+
+func clearOrigins(path string, origins map[string]string) {
+	for p := range origins {
+		if _, ok := underPath(p, path); ok {
+			delete(origins, p)
+		}
+	}
+}
+
+// underPath reports whether p is path or below it, returning the remainder.
+func underPath(p string, path string) (string, bool) {
+	if p == path {
+		return "", true
+	}
+
+	if !strings.HasPrefix(p, path) {
+		return "", false
+	}
+
+	rest := p[len(path):]
+	if path == "" || strings.HasPrefix(rest, ".") || strings.HasPrefix(rest, "[") {
+		return rest, true
+	}
+
+	return "", false
+}
+
+func containsScalar(seq *yaml.Node, value string) bool {
+	for _, item := range seq.Content {
+		if item.Kind == yaml.ScalarNode && item.Value == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// originOf returns the file the value at path, or its closest parent, came
+// from.
+func originOf(origins map[string]string, path string) string {
+	for {
+		if file, ok := origins[path]; ok {
+			return file
+		}
+
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			return origins[""]
+		}
+		path = path[:i]
+	}
+}
+
+// Origin returns the config file the value at path came from, e.g.
+// Origin("gitignore[1]"), or --set for values overridden on the command line.
+// Values that are not set anywhere have no origin.
+func (proj *Project) Origin(path string) string {
+	return proj.origins[path]
+}
+
+// SourceFiles returns the config file of the project followed by every file
+// it extends, directly or indirectly.
+func (proj *Project) SourceFiles() []string {
+	files := []string{}
+	seen := map[string]bool{}
+
+	if proj.configFile != "" {
+		files = append(files, proj.configFile)
+		seen[proj.configFile] = true
+	}
+
+	others := []string{}
+	for _, file := range proj.origins {
+		if !seen[file] && file != overrideOrigin {
+			others = append(others, file)
+			seen[file] = true
+		}
+	}
+	sort.Strings(others)
+
+	return append(files, others...)
+}
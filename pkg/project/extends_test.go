@@ -0,0 +1,148 @@
+package project_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = ioutil.WriteFile(path, []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestExtends(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	writeFiles(t, dir, map[string]string{
+		"org/base.yaml": `
+authorOrganization: acme
+githubToken: ACME_TOKEN
+gitignore: [.idea, .vscode]
+goTestArgs: ["-v"]
+workflowEnv:
+  GOPRIVATE: github.com/acme
+  FOO: base
+`,
+		"gojen.json": `{
+  "extends": ["org/base.yaml"],
+  "name": "test",
+  "repository": "github.com/acme/test",
+  "gitignore": [".idea", "dist"],
+  "goTestArgs": ["./..."],
+  "workflowEnv": {"FOO": "test"},
+}`,
+	})
+
+	proj, err := project.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = proj.ValidateConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proj.GetAuthorOrganization() != "acme" || proj.GetGitHubToken() != "ACME_TOKEN" {
+		t.Errorf("expected scalars to be inherited, got %s and %s", proj.GetAuthorOrganization(), proj.GetGitHubToken())
+	}
+
+	if got := *proj.Gitignore; !reflect.DeepEqual(got, []string{".idea", ".vscode", "dist"}) {
+		t.Errorf("expected gitignore to be appended, got %v", got)
+	}
+
+	if got := *proj.GoTestArgs; !reflect.DeepEqual(got, []string{"./..."}) {
+		t.Errorf("expected goTestArgs to be replaced, got %v", got)
+	}
+
+	env := *proj.WorkflowEnv
+	if len(env) != 2 || *env["GOPRIVATE"] != "github.com/acme" || *env["FOO"] != "test" {
+		t.Errorf("expected workflowEnv to be merged, got %d entries", len(env))
+	}
+
+	base := filepath.Join(dir, "org", "base.yaml")
+	cfg := filepath.Join(dir, "gojen.json")
+	origins := map[string]string{
+		"authorOrganization":    base,
+		"gitignore[1]":          base,
+		"gitignore[2]":          cfg,
+		"goTestArgs[0]":         cfg,
+		"workflowEnv.GOPRIVATE": base,
+		"workflowEnv.FOO":       cfg,
+		"name":                  cfg,
+	}
+	for path, want := range origins {
+		if got := proj.Origin(path); got != want {
+			t.Errorf("expected %s to come from %s, got %s", path, want, got)
+		}
+	}
+
+	if got := proj.SourceFiles(); !reflect.DeepEqual(got, []string{cfg, base}) {
+		t.Errorf("expected source files %v, got %v", []string{cfg, base}, got)
+	}
+}
+
+func TestExtendsErrors(t *testing.T) {
+	tests := map[string]struct {
+		files map[string]string
+		err   string
+	}{
+		"cycle": {
+			files: map[string]string{
+				"gojen.json":    `{"extends": ["org/base.json"], "name": "test"}`,
+				"org/base.json": `{"extends": ["../gojen.json"]}`,
+			},
+			err: "extends cycle:",
+		},
+		"missing": {
+			files: map[string]string{
+				"gojen.json": `{"extends": ["base.json"], "name": "test"}`,
+			},
+			err: "gojen.json: extends base.json:",
+		},
+		"invalid base": {
+			files: map[string]string{
+				"gojen.json": `{"extends": ["base.yaml"], "name": "test", "repository": "github.com/acme/test"}`,
+				"base.yaml":  "license: MIT\ngoTest: maybe\n",
+			},
+			err: "goTest: expected boolean, got string (base.yaml line 2:9)",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			chdir(t, dir)
+			writeFiles(t, dir, tc.files)
+
+			proj, err := project.GetConfig()
+			if err == nil {
+				err = proj.ValidateConfig()
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package project
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Hooks are the shell commands run before and after the built-in stages,
+// keyed by pre or post followed by the name of the stage. A failing hook
+// fails its stage, post hooks only run when the stage passed.
+type Hooks struct {
+	PreSynth   *[]string `yaml:"preSynth,omitempty" json:"preSynth,omitempty"`
+	PostSynth  *[]string `yaml:"postSynth,omitempty" json:"postSynth,omitempty"`
+	PreInit    *[]string `yaml:"preInit,omitempty" json:"preInit,omitempty"`
+	PostInit   *[]string `yaml:"postInit,omitempty" json:"postInit,omitempty"`
+	PreVendor  *[]string `yaml:"preVendor,omitempty" json:"preVendor,omitempty"`
+	PostVendor *[]string `yaml:"postVendor,omitempty" json:"postVendor,omitempty"`
+	PreTidy    *[]string `yaml:"preTidy,omitempty" json:"preTidy,omitempty"`
+	PostTidy   *[]string `yaml:"postTidy,omitempty" json:"postTidy,omitempty"`
+	PreFmt     *[]string `yaml:"preFmt,omitempty" json:"preFmt,omitempty"`
+	PostFmt    *[]string `yaml:"postFmt,omitempty" json:"postFmt,omitempty"`
+	PreLint    *[]string `yaml:"preLint,omitempty" json:"preLint,omitempty"`
+	PostLint   *[]string `yaml:"postLint,omitempty" json:"postLint,omitempty"`
+	PreTest    *[]string `yaml:"preTest,omitempty" json:"preTest,omitempty"`
+	PostTest   *[]string `yaml:"postTest,omitempty" json:"postTest,omitempty"`
+	PreBuild   *[]string `yaml:"preBuild,omitempty" json:"preBuild,omitempty"`
+	PostBuild  *[]string `yaml:"postBuild,omitempty" json:"postBuild,omitempty"`
+}
+
+func (proj *Project) GetHooks() *Hooks {
+	if proj.Hooks == nil {
+		return &Hooks{}
+	}
+	return proj.Hooks
+}
+
+// hookName returns the name of the hook run at when, pre or post, the task
+// called task, e.g. preTest.
+func hookName(when string, task string) string {
+	return when + strings.ToUpper(task[:1]) + task[1:]
+}
+
+// commands returns the commands of the hook called name.
+func (h *Hooks) commands(name string) []string {
+	v := reflect.ValueOf(h).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		key, _ := fieldKey(v.Type().Field(i), FormatJSON)
+		if key == name && !v.Field(i).IsNil() {
+			return *v.Field(i).Interface().(*[]string)
+		}
+	}
+
+	return nil
+}
+
+// runHooks runs the hook of t run at when, pre or post. The commands know
+// what they run around from the GOJEN_HOOK, GOJEN_TASK, GOJEN_STAGE and
+// GOJEN_CI environment variables.
+func (proj *Project) runHooks(t *Task, when string) error {
+	if !isBuiltinTask(t.Name) {
+		return nil
+	}
+
+	name := hookName(when, t.Name)
+	for _, step := range proj.GetHooks().commands(name) {
+		LogInfo(proj.stdout(), "running "+step, name)
+
+		c := proj.command("sh", "-c", step)
+		c.Env = []string{
+			"GOJEN_HOOK=" + name,
+			"GOJEN_TASK=" + t.Name,
+			"GOJEN_STAGE=" + t.Stage,
+			"GOJEN_CI=" + strconv.FormatBool(CI),
+		}
+
+		err := proj.run(c)
+		if err != nil {
+			LogFail(proj.stderr(), "running "+step+" failed", name)
+			return &StageError{Stage: t.Stage, Task: t.Name, Err: fmt.Errorf("%s hook %q: %w", name, step, err)}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package project_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestHooks(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		GoLinter:   project.Bool(false),
+		SkipVendor: project.Bool(true),
+		Hooks: &project.Hooks{
+			PreTest:   project.StringSlice([]string{"echo $GOJEN_HOOK $GOJEN_TASK $GOJEN_STAGE > hook.txt"}),
+			PostTest:  project.StringSlice([]string{"true"}),
+			PreBuild:  project.StringSlice([]string{"exit 2"}),
+			PostBuild: project.StringSlice([]string{"echo smoke"}),
+		},
+	}
+
+	// the hooks run, the commands of the built-in tasks are recorded
+	runner := &project.RecordingRunner{
+		Err: func(c *project.Command) error {
+			if c.Name == "sh" {
+				return project.ExecRunner{}.Run(c)
+			}
+			return nil
+		},
+	}
+	p.SetRunner(runner)
+
+	err := p.SetupProject()
+
+	var stageErr *project.StageError
+	if !errors.As(err, &stageErr) || stageErr.Stage != project.StageBuild {
+		t.Fatalf("expected the failing preBuild hook to fail the build stage, got %v", err)
+	}
+
+	expected := []string{
+		"go mod init github.com/test/test",
+		"go mod tidy",
+		"go fmt ./...",
+		"sh -c 'echo $GOJEN_HOOK $GOJEN_TASK $GOJEN_STAGE > hook.txt'",
+		"go test",
+		"sh -c true",
+		"sh -c 'exit 2'",
+	}
+	if got := runner.Strings(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected commands %q, got %q", expected, got)
+	}
+
+	b, err := ioutil.ReadFile("hook.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "preTest test test\n" {
+		t.Errorf("expected the hook to know the stage it runs for, got %q", b)
+	}
+}
+
+func TestHooksCheck(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeFiles(t, dir, map[string]string{
+		"go.mod":  "module github.com/test/test\n\ngo 1.17\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+
+	project.Check = true
+	t.Cleanup(func() {
+		project.Check = false
+	})
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		SkipVendor: project.Bool(true),
+		Hooks: &project.Hooks{
+			PreFmt:  project.StringSlice([]string{"echo > prefmt.txt"}),
+			PostFmt: project.StringSlice([]string{"echo > postfmt.txt"}),
+		},
+	}
+
+	// the hooks of the stages --check verifies instead of running would
+	// change the tree
+	err := p.RunTask("fmt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"prefmt.txt", "postfmt.txt"} {
+		if _, err := os.Stat(name); err == nil {
+			t.Errorf("expected the hook writing %s not to run with --check", name)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stripJSONC turns JSON with comments and trailing commas into plain JSON.
+// Comments and trailing commas are replaced by spaces instead of being
+// removed, so byte offsets in the result match the input, which keeps
+// error positions reported by encoding/json usable.
+func stripJSONC(b []byte) ([]byte, error) {
+	out := make([]byte, len(b))
+	copy(out, b)
+
+	inString := false
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := bytes.Index(out[i+2:], []byte("*/"))
+			if end < 0 {
+				line, col := position(b, i)
+				return nil, fmt.Errorf("%d:%d: unterminated comment", line, col)
+			}
+			for j := i; j < i+2+end+2; j++ {
+				if out[j] != '\n' {
+					out[j] = ' '
+				}
+			}
+			i += 2 + end + 1
+		}
+	}
+
+	inString = false
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case ',':
+			j := i + 1
+			for j < len(out) && isJSONSpace(out[j]) {
+				j++
+			}
+			if j < len(out) && (out[j] == '}' || out[j] == ']') {
+				out[i] = ' '
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// unmarshalJSONC decodes JSON with comments into v. Errors carry the line and
+// column of the offending input instead of a byte offset.
+func unmarshalJSONC(b []byte, v interface{}) error {
+	stripped, err := stripJSONC(b)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(stripped, v)
+	if err == nil {
+		return nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line, col := position(b, int(syntaxErr.Offset)-1)
+		return fmt.Errorf("%d:%d: %s", line, col, syntaxErr.Error())
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		line, col := position(b, int(typeErr.Offset)-1)
+		return fmt.Errorf("%d:%d: %s", line, col, typeErr.Error())
+	}
+
+	return err
+}
+
+// jsonNode parses JSON with comments, which must be valid, into a document
+// node the way encoding/json reads it: escapes such as \/ and \u00e9 are
+// decoded, and the last of duplicate keys wins. The nodes carry the line and
+// column they start at in b.
+func jsonNode(b []byte) (*yaml.Node, error) {
+	stripped, err := stripJSONC(b)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(stripped))
+	dec.UseNumber()
+
+	node, err := jsonValue(dec, b, stripped)
+	if err != nil {
+		return nil, err
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}}, nil
+}
+
+// jsonValue reads the next value of dec into a node.
+func jsonValue(dec *json.Decoder, b []byte, stripped []byte) (*yaml.Node, error) {
+	start := int(dec.InputOffset())
+	for start < len(stripped) && (isJSONSpace(stripped[start]) || stripped[start] == ':' || stripped[start] == ',') {
+		start++
+	}
+	line, col := position(b, start)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &yaml.Node{Line: line, Column: col}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		if v == '{' {
+			node.Kind, node.Tag, node.Style = yaml.MappingNode, "!!map", yaml.FlowStyle
+			keys := map[string]int{}
+			for dec.More() {
+				key, err := jsonValue(dec, b, stripped)
+				if err != nil {
+					return nil, err
+				}
+				value, err := jsonValue(dec, b, stripped)
+				if err != nil {
+					return nil, err
+				}
+
+				// encoding/json keeps the last of duplicate keys
+				if i, ok := keys[key.Value]; ok {
+					node.Content[i], node.Content[i+1] = key, value
+					continue
+				}
+				keys[key.Value] = len(node.Content)
+				node.Content = append(node.Content, key, value)
+			}
+		} else {
+			node.Kind, node.Tag, node.Style = yaml.SequenceNode, "!!seq", yaml.FlowStyle
+			for dec.More() {
+				value, err := jsonValue(dec, b, stripped)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, value)
+			}
+		}
+
+		// the closing delimiter
+		_, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+	case string:
+		node.Kind, node.Tag, node.Value, node.Style = yaml.ScalarNode, "!!str", v, yaml.DoubleQuotedStyle
+	case json.Number:
+		node.Kind, node.Tag, node.Value = yaml.ScalarNode, "!!int", v.String()
+		if strings.ContainsAny(v.String(), ".eE") {
+			node.Tag = "!!float"
+		}
+	case bool:
+		node.Kind, node.Tag, node.Value = yaml.ScalarNode, "!!bool", strconv.FormatBool(v)
+	case nil:
+		node.Kind, node.Tag, node.Value = yaml.ScalarNode, "!!null", "null"
+	}
+
+	return node, nil
+}
+
+// position converts a byte offset into a 1-based line and column.
+func position(b []byte, offset int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(b) {
+		offset = len(b)
+	}
+
+	line := 1 + bytes.Count(b[:offset], []byte("\n"))
+	col := offset - bytes.LastIndexByte(b[:offset], '\n')
+
+	return line, col
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
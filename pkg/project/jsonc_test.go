@@ -0,0 +1,110 @@
+package project_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestJSONCConfig(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	contents := `{
+  // the binary name
+  "name": "test",
+  "repository": "github.com/test/test", /* module path */
+  "description": "not // a comment",
+  "gitignore": [
+    ".vscode",
+    ".idea",
+  ],
+}
+`
+	err := ioutil.WriteFile(filepath.Join(dir, "gojen.json"), []byte(contents), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proj, err := project.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proj.GetName() != "test" {
+		t.Errorf("expected test, got %s", proj.GetName())
+	}
+
+	if proj.GetDescription() != "not // a comment" {
+		t.Errorf("expected %q, got %q", "not // a comment", proj.GetDescription())
+	}
+
+	if !reflect.DeepEqual(proj.GetGitignore(), []string{".vscode", ".idea"}) {
+		t.Errorf("expected [.vscode .idea], got %s", proj.GetGitignore())
+	}
+}
+
+func TestJSONCConfigErrors(t *testing.T) {
+	tests := map[string]string{
+		"{\n  \"name\": \"test\"\n  \"repository\": \"github.com/test/test\"\n}\n": "gojen.json:3:3: invalid character '\"' after object key:value pair",
+		"{\n  /* \"name\": \"test\"\n}\n":                                          "gojen.json:2:3: unterminated comment",
+		"{\n  \"name\": 1,\n  \"repository\": \"github.com/test/test\"\n}\n":       "invalid config gojen.json:\n  name: expected string, got number (line 2:11)",
+	}
+
+	for contents, expected := range tests {
+		dir := t.TempDir()
+		chdir(t, dir)
+
+		err := ioutil.WriteFile(filepath.Join(dir, "gojen.json"), []byte(contents), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proj, err := project.GetConfig()
+		if err == nil {
+			err = proj.ValidateConfig()
+		}
+
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected %q, got %v", expected, err)
+		}
+	}
+}
+
+func TestJSONConfigEscapes(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	contents := `{
+  "name": "a\/b",
+  "description": "caf\u00e9 \ud83d\ude00",
+  "repository": "github.com/test/old",
+  "repository": "github.com/test/test"
+}
+`
+	err := ioutil.WriteFile(filepath.Join(dir, "gojen.json"), []byte(contents), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proj, err := project.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proj.GetName() != "a/b" {
+		t.Errorf("expected a/b, got %q", proj.GetName())
+	}
+
+	if proj.GetDescription() != "café 😀" {
+		t.Errorf("expected %q, got %q", "café 😀", proj.GetDescription())
+	}
+
+	// encoding/json keeps the last of duplicate keys
+	if proj.GetRepository() != "github.com/test/test" {
+		t.Errorf("expected github.com/test/test, got %q", proj.GetRepository())
+	}
+}
@@ -6,20 +6,22 @@ import (
 	"github.com/kyokomi/emoji/v2"
 )
 
+// Nothing runs in a dry run, so the Log functions print nothing while DryRun
+// is set, leaving only the plan.
 func LogSuccess(w io.Writer, str string, function string) {
-	if str != "" {
+	if str != "" && !DryRun {
 		emoji.Fprintf(w, ":white_check_mark: | %s | %s\n", function, str)
 	}
 }
 
 func LogFail(w io.Writer, str string, function string) {
-	if str != "" {
+	if str != "" && !DryRun {
 		emoji.Fprintf(w, ":x: | %s | %s\n", function, str)
 	}
 }
 
 func LogInfo(w io.Writer, str string, function string) {
-	if str != "" {
+	if str != "" && !DryRun {
 		emoji.Fprintf(w, ":information: | %s | %s\n", function, str)
 	}
 }
@@ -0,0 +1,145 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Overrides are key=value pairs set using --set, they are applied over the
+// config when it is loaded. Keys are paths such as workflowEnv.FOO and values
+// are parsed as YAML, e.g. goTest=false or gitignore=[dist].
+var Overrides []string
+
+// overrideOrigin is recorded as the origin of overridden values.
+const overrideOrigin = "--set"
+
+func (proj *Project) applyOverrides() error {
+	for _, o := range Overrides {
+		path, value, err := parseOverride(o)
+		if err != nil {
+			return err
+		}
+
+		err = setNode(proj.raw, path, value)
+		if err != nil {
+			return fmt.Errorf("--set %s: %s", o, err.Error())
+		}
+
+		clearOrigins(path, proj.origins)
+		recordOrigins(value, path, overrideOrigin, proj.origins)
+	}
+
+	return nil
+}
+
+func parseOverride(o string) (string, *yaml.Node, error) {
+	i := strings.Index(o, "=")
+	if i <= 0 {
+		return "", nil, fmt.Errorf("--set %s: expected key=value", o)
+	}
+
+	value, err := parseValue(o[i+1:])
+	if err != nil {
+		return "", nil, fmt.Errorf("--set %s: %s", o, err.Error())
+	}
+
+	return o[:i], value, nil
+}
+
+// parseValue parses a value given on the command line as YAML, an empty
+// value is an empty string.
+func parseValue(s string) (*yaml.Node, error) {
+	doc := &yaml.Node{}
+	err := yaml.Unmarshal([]byte(s), doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}, nil
+	}
+
+	// positions refer to the command line, not to a config file
+	clearPositions(doc.Content[0])
+
+	return doc.Content[0], nil
+}
+
+func clearPositions(node *yaml.Node) {
+	node.Line = 0
+	node.Column = 0
+	for _, child := range node.Content {
+		clearPositions(child)
+	}
+}
+
+// setNode sets the value at path below root, creating the objects and lists
+// leading up to it. An index one past the end of a list appends to it.
+func setNode(root *yaml.Node, path string, value *yaml.Node) error {
+	parts := splitPath(path)
+	if len(parts) == 0 {
+		return errors.New("empty key")
+	}
+
+	n := root
+	for i, part := range parts {
+		var child *yaml.Node
+
+		switch {
+		case part.index < 0 && n.Kind == yaml.MappingNode:
+			child = mappingValue(n, part.key)
+			if child == nil {
+				child = &yaml.Node{}
+				n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part.key}, child)
+			}
+		case part.index >= 0 && n.Kind == yaml.SequenceNode:
+			if part.index > len(n.Content) {
+				return fmt.Errorf("%s has %d entries", joinParts(parts[:i]), len(n.Content))
+			}
+
+			if part.index == len(n.Content) {
+				child = &yaml.Node{}
+				n.Content = append(n.Content, child)
+			} else {
+				child = n.Content[part.index]
+			}
+		case part.index < 0:
+			return fmt.Errorf("%s is not an object", joinParts(parts[:i]))
+		default:
+			return fmt.Errorf("%s is not a list", joinParts(parts[:i]))
+		}
+
+		if i == len(parts)-1 {
+			*child = *value
+			break
+		}
+
+		if child.Kind == 0 || child.Tag == "!!null" {
+			if parts[i+1].index >= 0 {
+				*child = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			} else {
+				*child = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			}
+		}
+		n = child
+	}
+
+	return nil
+}
+
+// joinParts is the inverse of splitPath.
+func joinParts(parts []pathPart) string {
+	path := ""
+	for _, part := range parts {
+		if part.index >= 0 {
+			path = fmt.Sprintf("%s[%d]", path, part.index)
+		} else {
+			path = joinPath(path, part.key)
+		}
+	}
+
+	return path
+}
@@ -0,0 +1,195 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Presets of the kinds of projects gojen creates, selected using the preset
+// field.
+const (
+	PresetCLI     = "cli"
+	PresetLibrary = "library"
+	PresetService = "service"
+)
+
+// Presets returns the presets a project can use.
+func Presets() []string {
+	return []string{PresetCLI, PresetLibrary, PresetService}
+}
+
+// presetDeps are the modules the files scaffolded for a preset import.
+var presetDeps = map[string][]string{
+	PresetCLI: {"github.com/spf13/cobra@v1.2.1"},
+}
+
+var packageNameRe = regexp.MustCompile(`[^a-z0-9_]`)
+
+// Scaffold writes the source files of a new project according to its preset,
+// files that already exist are left alone.
+func (proj *Project) Scaffold() error {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range proj.scaffoldFiles() {
+		err = proj.createFile(filepath.Join(pwd, f.path), []byte(f.contents))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addPresetDeps requires the modules imported by the scaffolded files, so
+// go mod vendor can find them in a freshly initialised module.
+func (proj *Project) addPresetDeps() error {
+	for _, dep := range presetDeps[proj.GetPreset()] {
+		LogInfo(proj.stdout(), "running go get "+dep, "Setup")
+
+		get := proj.command("go", "get", dep)
+		err := proj.run(get)
+		if err != nil {
+			LogFail(proj.stderr(), "running go get "+dep+" failed", "Setup")
+			return &StageError{Stage: StageSetup, Err: err}
+		}
+	}
+
+	return nil
+}
+
+type scaffoldFile struct {
+	path     string
+	contents string
+}
+
+func (proj *Project) scaffoldFiles() []scaffoldFile {
+	switch proj.GetPreset() {
+	case PresetLibrary:
+		pkg := proj.packageName()
+		return []scaffoldFile{{
+			path: pkg + ".go",
+			contents: fmt.Sprintf(`// Package %s was created with gojen, have fun :-)
+package %s
+`, pkg, pkg),
+		}}
+	case PresetCLI:
+		return []scaffoldFile{
+			{
+				path: "main.go",
+				contents: fmt.Sprintf(`package main
+
+import "%s/cmd"
+
+func main() {
+	cmd.Execute()
+}
+`, proj.GetRepository()),
+			},
+			{
+				path: filepath.Join("cmd", "root.go"),
+				contents: fmt.Sprintf(`package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// rootCmd represents the base command when called without any subcommands
+var rootCmd = &cobra.Command{
+	Use:   %q,
+	Short: %q,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("project created with gojen, have fun :-)")
+	},
+}
+
+// Execute runs the root command, exiting with 1 when it fails.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+`, proj.GetName(), proj.GetDescription()),
+			},
+		}
+	case PresetService:
+		return []scaffoldFile{
+			{
+				path: "main.go",
+				contents: `package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+)
+
+func main() {
+	addr := ":8080"
+	if port := os.Getenv("PORT"); port != "" {
+		addr = ":" + port
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "project created with gojen, have fun :-)")
+	})
+
+	log.Printf("listening on %s", addr)
+	log.Fatal(http.ListenAndServe(addr, mux))
+}
+`,
+			},
+			{
+				path: "Dockerfile",
+				contents: fmt.Sprintf(`FROM golang:%s AS build
+WORKDIR /src
+COPY . .
+RUN CGO_ENABLED=0 go build -o /out/%s .
+
+FROM gcr.io/distroless/static
+COPY --from=build /out/%s /%s
+EXPOSE 8080
+ENTRYPOINT ["/%s"]
+`, proj.GetGoVersion(), proj.GetName(), proj.GetName(), proj.GetName(), proj.GetName()),
+			},
+		}
+	default:
+		return []scaffoldFile{{
+			path: "main.go",
+			contents: `package main
+import (
+	"fmt"
+)
+
+func main () {
+	fmt.Println("project created with gojen, have fun :-)")
+}
+`,
+		}}
+	}
+}
+
+// packageName returns the name of the package of a library, derived from the
+// last element of its module path.
+func (proj *Project) packageName() string {
+	parts := strings.Split(proj.GetRepository(), "/")
+	name := packageNameRe.ReplaceAllString(strings.ToLower(parts[len(parts)-1]), "")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "lib" + name
+	}
+
+	return name
+}
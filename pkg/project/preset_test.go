@@ -0,0 +1,155 @@
+package project_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestPresets(t *testing.T) {
+	tests := map[string]struct {
+		files   map[string]string
+		missing []string
+		goBuild bool
+	}{
+		"": {
+			files:   map[string]string{"main.go": "project created with gojen"},
+			goBuild: true,
+		},
+		project.PresetLibrary: {
+			files:   map[string]string{"mylib.go": "package mylib"},
+			missing: []string{"main.go", ".github/workflows/upload-binary.yml"},
+			goBuild: false,
+		},
+		project.PresetCLI: {
+			files: map[string]string{
+				"main.go":     `import "github.com/test/my-lib/cmd"`,
+				"cmd/root.go": `Use:   "test"`,
+			},
+			goBuild: true,
+		},
+		project.PresetService: {
+			files: map[string]string{
+				"main.go":    "http.ListenAndServe",
+				"Dockerfile": "FROM golang:1.17 AS build",
+			},
+			goBuild: true,
+		},
+	}
+
+	for preset, tc := range tests {
+		t.Run(preset, func(t *testing.T) {
+			dir := t.TempDir()
+			chdir(t, dir)
+
+			p := &project.Project{
+				Name:       project.String("test"),
+				Repository: project.String("github.com/test/my-lib"),
+				GoVersion:  project.String("1.17"),
+				Preset:     project.String(preset),
+			}
+
+			err := p.ValidateConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if p.IsGoBuild() != tc.goBuild {
+				t.Errorf("expected goBuild %v, got %v", tc.goBuild, p.IsGoBuild())
+			}
+
+			err = p.Scaffold()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = p.CreateReleaseWorkflow()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for name, expected := range tc.files {
+				b, err := ioutil.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Error(err)
+					continue
+				}
+
+				if !strings.Contains(string(b), expected) {
+					t.Errorf("expected %q in %s:\n%s", expected, name, b)
+				}
+			}
+
+			for _, name := range tc.missing {
+				if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+					t.Errorf("expected %s to not exist", name)
+				}
+			}
+		})
+	}
+}
+
+func TestLibraryPresetRemovesUploadBinary(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	writeFiles(t, dir, map[string]string{".github/workflows/upload-binary.yml": "name: Upload Binary\n"})
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/my-lib"),
+		GoVersion:  project.String("1.17"),
+		Preset:     project.String(project.PresetLibrary),
+		Release:    project.Bool(true),
+	}
+
+	// the workflow left over from another preset is part of the diff
+	changes, err := p.Diff()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var removed *project.FileChange
+	for _, c := range changes {
+		if filepath.Base(c.Path) == "upload-binary.yml" {
+			removed = c
+		}
+	}
+	if removed == nil || removed.Status != project.FileRemoved {
+		t.Fatalf("expected the diff to remove upload-binary.yml, got %+v", removed)
+	}
+
+	var diff strings.Builder
+	err = project.WriteDiff(&diff, []*project.FileChange{removed}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff.String(), "+++ /dev/null\n") || !strings.Contains(diff.String(), "-name: Upload Binary\n") {
+		t.Errorf("expected a diff removing upload-binary.yml, got:\n%s", diff.String())
+	}
+
+	err = p.CreateReleaseWorkflow()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".github/workflows/upload-binary.yml")); !os.IsNotExist(err) {
+		t.Errorf("expected upload-binary.yml to be removed, got %v", err)
+	}
+}
+
+func TestInvalidPreset(t *testing.T) {
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Preset:     project.String("daemon"),
+	}
+
+	err := p.ValidateConfig()
+	if err == nil || !strings.Contains(err.Error(), `preset: "daemon" is not a preset`) {
+		t.Errorf("expected invalid preset error, got %v", err)
+	}
+}
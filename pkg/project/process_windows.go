@@ -0,0 +1,21 @@
+package project
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup does nothing on Windows, where there are no process groups
+// to start cmd in.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// terminateProcessGroup kills cmd, as Windows has no signal asking it to
+// stop.
+func terminateProcessGroup(cmd *exec.Cmd) {
+	_ = cmd.Process.Kill()
+}
+
+// killProcessGroup kills cmd, the processes it started keep running on
+// Windows.
+func killProcessGroup(cmd *exec.Cmd) {
+	_ = cmd.Process.Kill()
+}
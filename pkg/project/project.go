@@ -1,17 +1,20 @@
 package project
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
-	"os/exec"
-	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/Hunter-Thompson/gojen/pkg/github"
 	"github.com/Hunter-Thompson/gojen/pkg/license"
+	"gopkg.in/yaml.v3"
 )
 
 var CI bool
@@ -19,6 +22,8 @@ var CI bool
 type IProject interface {
 	WriteConfig() error
 	SetupProject() error
+	Diff() ([]*FileChange, error)
+	Results() []*StageResult
 	SetGitignore() error
 	CreateReadme() error
 	RunTest() error
@@ -50,9 +55,18 @@ type IProject interface {
 	GetGoBuildArgs() []string
 	GetWorkflowEnv() *map[string]*string
 	GetLicense() string
+	GetPreset() string
+
+	RunTask(name string) error
+	SetContext(ctx context.Context)
+	AllTasks() []*Task
 }
 
 type Project struct {
+	Schema  *string   `yaml:"$schema,omitempty" json:"$schema,omitempty"`
+	Extends *[]string `yaml:"extends,omitempty" json:"extends,omitempty"`
+	Preset  *string   `yaml:"preset" json:"preset"`
+
 	Name        *string `yaml:"name" json:"name"`
 	Description *string `yaml:"description" json:"description"`
 	Repository  *string `yaml:"repository"  json:"repository"`
@@ -73,6 +87,7 @@ type Project struct {
 	IsGojen              *bool     `yaml:"isGojen" json:"isGojen"`
 	CodeCov              *bool     `yaml:"codeCov" json:"codeCov"`
 	TestEnvVars          *[]string `yaml:"testEnvVars" json:"testEnvVars"`
+	TestEnvFile          *string   `yaml:"testEnvFile" json:"testEnvFile"`
 
 	Gitignore  *[]string `yaml:"gitignore" json:"gitignore"`
 	CodeOwners *[]string `yaml:"codeOwners" json:"codeOwners"`
@@ -82,12 +97,35 @@ type Project struct {
 
 	GoLinter     *bool               `yaml:"goLinter" json:"goLinter"`
 	GoTest       *bool               `yaml:"goTest" json:"goTest"`
-	GoTestArgs   *[]string           `yaml:"goTestArgs" json:"goTestArgs"`
+	GoTestArgs   *[]string           `yaml:"goTestArgs" json:"goTestArgs" merge:"replace"`
 	GoBuild      *bool               `yaml:"goBuild" json:"goBuild"`
-	GoBuildArgs  *[]string           `yaml:"goBuildArgs" json:"goBuildArgs"`
+	GoBuildArgs  *[]string           `yaml:"goBuildArgs" json:"goBuildArgs" merge:"replace"`
 	WorkflowEnv  *map[string]*string `yaml:"workflowEnv" json:"workflowEnv"`
 	PrependSteps *[]*github.JobStep  `yaml:"prependSteps" json:"prependSteps"`
 	AppendSteps  *[]*github.JobStep  `yaml:"apendSteps" json:"apendSteps"`
+
+	Tasks    *map[string]*TaskConfig `yaml:"tasks" json:"tasks"`
+	Hooks    *Hooks                  `yaml:"hooks" json:"hooks"`
+	Timeouts *map[string]*string     `yaml:"timeouts" json:"timeouts"`
+
+	configFile string
+	doc        *yaml.Node
+	decoded    *yaml.Node
+	raw        *yaml.Node
+	origins    map[string]string
+	changes    []*FileChange
+	// inMemory makes writeFile only record changes, see Diff.
+	inMemory bool
+
+	runner Runner
+	ctx    context.Context
+	out    io.Writer
+	errOut io.Writer
+	// task is the name of the task running, outputDir where the output of
+	// tasks is captured with OutputJSON.
+	task      string
+	outputDir string
+	results   []*StageResult
 }
 
 func InitProject() (IProject, error) {
@@ -104,70 +142,16 @@ func InitProject() (IProject, error) {
 	return proj, nil
 }
 
-func (proj *Project) ValidateConfig() error {
-	if proj.GetName() == "" {
-		return errors.New("name is missing in config")
-	}
-
-	if proj.GetRepository() == "" {
-		return errors.New("repository is missing in config")
-	}
-
-	return nil
-}
-
-func GetConfig() (*Project, error) {
-	proj := &Project{}
-
-	pwd, err := os.Getwd()
-	if err != nil {
-		return nil, err
-	}
-
-	cfgPath := fmt.Sprintf("%s/gojen.json", pwd)
-
-	b, err := ioutil.ReadFile(cfgPath)
-	if err != nil {
-		return nil, err
-	}
-
-	err = json.Unmarshal(b, &proj)
-	if err != nil {
-		return nil, err
-	}
-
-	return proj, nil
-}
-
-func (proj *Project) WriteConfig() error {
-	pwd, err := os.Getwd()
-	if err != nil {
-		return err
-	}
-
-	cfgPath := fmt.Sprintf("%s/gojen.json", pwd)
-
-	b, err := json.MarshalIndent(proj, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	err = ioutil.WriteFile(cfgPath, b, 0o644)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
+// SetupProject runs the default task, generating the project files and then
+// vendoring, tidying, formatting, linting, testing and building the project.
 func (proj *Project) SetupProject() error {
-	if proj.IsCodeCov() {
-		err := proj.AddCodeCov()
-		if err != nil {
-			return err
-		}
-	}
+	return proj.RunTask(DefaultTask)
+}
 
+// Synth generates the files of the project: its license, README, codeowners,
+// workflows and .gitignore, along with the source files scaffolded for a new
+// project.
+func (proj *Project) Synth() error {
 	err := proj.AddLicense()
 	if err != nil {
 		return err
@@ -180,7 +164,7 @@ func (proj *Project) SetupProject() error {
 		}
 	}
 
-	if !reflect.DeepEqual(proj.CodeOwners, &[]string{}) {
+	if len(proj.GetCodeOwners()) > 0 {
 		err := proj.SetCodeOwners()
 		if err != nil {
 			return err
@@ -206,160 +190,162 @@ func (proj *Project) SetupProject() error {
 		return err
 	}
 
-	modInit := exec.Command("go", "mod", "init", proj.GetRepository())
-	vendor := exec.Command("go", "mod", "vendor")
-	tidy := exec.Command("go", "mod", "tidy")
-	gofmt := exec.Command("go", "fmt")
+	return proj.Scaffold()
+}
 
+// InitModule runs go mod init when the project has no go.mod yet.
+func (proj *Project) InitModule() error {
 	pwd, err := os.Getwd()
 	if err != nil {
 		return err
 	}
 
-	if _, err := os.Stat(pwd + "/main.go"); errors.Is(err, os.ErrNotExist) {
-		c := `package main
-import (
-	"fmt"
-)
-
-func main () {
-	fmt.Println("project created with gojen, have fun :-)")
-}`
-		err := ioutil.WriteFile(pwd+"/main.go", []byte(c+"\n"), 0o644)
-		if err != nil {
-			return err
-		}
+	if _, err := os.Stat(pwd + "/go.mod"); !errors.Is(err, os.ErrNotExist) {
+		return nil
 	}
 
+	LogInfo(proj.stdout(), "running go mod init", "Setup")
+
+	modInit := proj.command("go", "mod", "init", proj.GetRepository())
+	err = proj.run(modInit)
 	if err != nil {
-		return err
+		LogFail(proj.stderr(), "running go mod vendor init failed", "Setup")
+		return &StageError{Stage: StageSetup, Err: err}
 	}
 
-	if _, err := os.Stat(pwd + "/go.mod"); errors.Is(err, os.ErrNotExist) {
-		LogInfo(os.Stdout, "running go mod init", "Setup")
-
-		modInit.Stdout = os.Stdout
-		modInit.Stderr = os.Stderr
+	return proj.addPresetDeps()
+}
 
-		err := modInit.Run()
-		if err != nil {
-			LogFail(os.Stderr, "running go mod vendor init failed", "Setup")
-			return errors.New("logged to stderr")
-		}
-	}
+func (proj *Project) RunVendor() error {
+	LogInfo(proj.stdout(), "running go mod vendor", "Setup")
 
+	vendor := proj.command("go", "mod", "vendor")
+	err := proj.run(vendor)
 	if err != nil {
-		return err
+		LogFail(proj.stderr(), "running go mod vendor failed", "Setup")
+		return &StageError{Stage: StageSetup, Err: err}
 	}
 
-	if proj.SkipVendor == nil || !*proj.SkipVendor {
-		LogInfo(os.Stdout, "running go mod vendor", "Setup")
+	return nil
+}
 
-		vendor.Stdout = os.Stdout
-		vendor.Stderr = os.Stderr
+func (proj *Project) RunTidy() error {
+	LogInfo(proj.stdout(), "running go mod tidy", "Setup")
 
-		err = vendor.Run()
-		if err != nil {
-			LogFail(os.Stderr, "running go mod vendor failed", "Setup")
-			return errors.New("logged to stderr")
-		}
+	tidy := proj.command("go", "mod", "tidy")
+	err := proj.run(tidy)
+	if err != nil {
+		LogFail(proj.stderr(), "running go mod tidy failed", "Setup")
+		return &StageError{Stage: StageSetup, Err: err}
 	}
 
-	if proj.SkipTidy == nil || !*proj.SkipTidy {
-		LogInfo(os.Stdout, "running go mod tidy", "Setup")
+	return nil
+}
 
-		tidy.Stdout = os.Stdout
-		tidy.Stderr = os.Stderr
+func (proj *Project) RunFmt() error {
+	LogInfo(proj.stdout(), "running go fmt", "Setup")
 
-		err = tidy.Run()
-		if err != nil {
-			LogFail(os.Stderr, "running go mod tidy failed", "Setup")
-			return errors.New("logged to stderr")
-		}
+	gofmt := proj.command("go", "fmt", "./...")
+	err := proj.run(gofmt)
+	if err != nil {
+		LogFail(proj.stderr(), "running go fmt failed", "Setup")
+		return &StageError{Stage: StageSetup, Err: err}
 	}
 
-	LogInfo(os.Stdout, "running go fmt", "Setup")
-
-	gofmt.Stdout = os.Stdout
-	gofmt.Stderr = os.Stderr
+	return nil
+}
 
-	err = gofmt.Run()
-	if err != nil {
-		LogFail(os.Stderr, "running go fmt failed", "Setup")
-		return errors.New("logged to stderr")
+func (proj *Project) RunTest() error {
+	args := []string{"test"}
+	if proj.IsCodeCov() {
+		args = append(args, "-coverprofile=coverage.txt", "-covermode=atomic")
 	}
+	args = append(args, proj.GetGoTestArgs()...)
 
-	if !CI {
-		if proj.IsGoLinter() {
-			err = proj.RunLinter()
-			if err != nil {
-				return err
-			}
-		}
+	env, err := proj.testEnv()
+	if err != nil {
+		LogFail(proj.stderr(), err.Error(), "Test")
+		return &StageError{Stage: StageTest, Err: err}
 	}
 
-	if proj.IsGoTest() {
-		err = proj.RunTest()
-		if err != nil {
-			return err
-		}
-	}
+	LogInfo(proj.stdout(), "running go test", "Test")
 
-	if proj.IsGoBuild() {
-		err = proj.RunBuild()
-		if err != nil {
-			return err
-		}
+	test := proj.command("go", args...)
+	test.Env = env
+	err = proj.run(test)
+	if err != nil {
+		LogFail(proj.stderr(), "running go test failed", "Test")
+		return &StageError{Stage: StageTest, Err: err}
 	}
 
+	LogSuccess(proj.stdout(), "go test passed", "Test")
 	return nil
 }
 
-func (proj *Project) RunTest() error {
-	if proj.GoTestArgs == nil {
-		proj.GoTestArgs = &[]string{}
-	}
-	*proj.GoTestArgs = append([]string{"test"}, *proj.GoTestArgs...)
-
-	LogInfo(os.Stdout, "running go test", "Test")
-
-	test := exec.Command("go", *proj.GoTestArgs...)
+func (proj *Project) RunBuild() error {
+	args := append([]string{"build"}, proj.GetGoBuildArgs()...)
 
-	test.Stdout = os.Stdout
-	test.Stderr = os.Stderr
+	LogInfo(proj.stdout(), "running go build", "Build")
 
-	err := test.Run()
+	build := proj.command("go", args...)
+	err := proj.run(build)
 	if err != nil {
-		LogFail(os.Stderr, "running go test failed", "Test")
-		return errors.New("logged to stderr")
+		LogFail(proj.stderr(), "running go build failed", "Build")
+		return &StageError{Stage: StageBuild, Err: err}
 	}
 
-	LogSuccess(os.Stdout, "go test passed", "Test")
+	LogSuccess(proj.stdout(), "go build passed", "Build")
+
 	return nil
 }
 
-func (proj *Project) RunBuild() error {
-	if proj.GoBuildArgs == nil {
-		proj.GoBuildArgs = &[]string{}
+// buildOutput returns the binary go build writes: the path given to -o in
+// the goBuildArgs, or the last element of the module path, the one before
+// it for a major version suffix such as /v2.
+func (proj *Project) buildOutput() string {
+	args := proj.GetGoBuildArgs()
+	for i, arg := range args {
+		switch {
+		case (arg == "-o" || arg == "--o") && i+1 < len(args):
+			return args[i+1]
+		case strings.HasPrefix(arg, "-o="):
+			return strings.TrimPrefix(arg, "-o=")
+		case strings.HasPrefix(arg, "--o="):
+			return strings.TrimPrefix(arg, "--o=")
+		}
 	}
-	*proj.GoBuildArgs = append([]string{"build"}, *proj.GoBuildArgs...)
 
-	LogInfo(os.Stdout, "running go build", "Build")
+	elems := strings.Split(modulePath(proj.GetRepository()), "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && majorVersionRe.MatchString(name) {
+		name = elems[len(elems)-2]
+	}
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
 
-	build := exec.Command("go", *proj.GoBuildArgs...)
+	return name
+}
 
-	build.Stdout = os.Stdout
-	build.Stderr = os.Stderr
+// majorVersionRe matches the major version suffix of a module path.
+var majorVersionRe = regexp.MustCompile(`^v[0-9]+$`)
 
-	err := build.Run()
+// modulePath returns the module path in go.mod, or fallback when there is
+// no go.mod yet.
+func modulePath(fallback string) string {
+	b, err := ioutil.ReadFile("go.mod")
 	if err != nil {
-		LogFail(os.Stderr, "running go build failed", "Build")
-		return errors.New("logged to stderr")
+		return fallback
 	}
 
-	LogSuccess(os.Stdout, "go build passed", "Build")
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
 
-	return nil
+	return fallback
 }
 
 func (proj *Project) AddLicense() error {
@@ -372,41 +358,22 @@ func (proj *Project) AddLicense() error {
 		return err
 	}
 
-	if _, err := os.Stat(pwd + "/LICENSE"); errors.Is(err, os.ErrNotExist) {
-		LogInfo(os.Stdout, "adding license", "Setup")
-		licenses := []string{
-			"Apache-2.0",
-			"Artistic-2.0",
-			"GPL-3.0-WITH-GCC-exception-3.1",
-			"LGPL-2.1-or-later  ",
-			"MIT-0",
-			"MPL-2.0",
-			"PHP-3.01",
-			"Unlicense",
-			"ZPL-2.1",
-			"Artistic-1.0",
-			"GPL-2.0-or-later",
-			"GPL-3.0-or-later",
-			"LGPL-3.0-or-later",
-			"MIT",
-			"OFL-1.1",
-			"Ruby",
-			"WTFPL",
-		}
-
-		if Contains(licenses, proj.GetLicense()) {
-			b, err := license.Asset("license-text/" + proj.GetLicense() + ".txt")
-			if err != nil {
-				return err
-			}
+	if _, err := os.Stat(pwd + "/LICENSE"); errors.Is(err, os.ErrNotExist) && !proj.inMemory {
+		LogInfo(proj.stdout(), "adding license", "Setup")
+	}
 
-			err = ioutil.WriteFile(pwd+"/LICENSE", b, 0o644)
-			if err != nil {
-				return err
-			}
+	if Contains(Licenses(), proj.GetLicense()) {
+		b, err := license.Asset("license-text/" + proj.GetLicense() + ".txt")
+		if err != nil {
+			return err
 		}
 
+		err = proj.createFile(pwd+"/LICENSE", b)
+		if err != nil {
+			return err
+		}
 	}
+
 	return nil
 }
 
@@ -417,10 +384,17 @@ func (proj *Project) SetGitignore() error {
 	}
 
 	gitignorePath := fmt.Sprintf("%s/.gitignore", pwd)
-	*proj.Gitignore = append(*proj.Gitignore, *proj.Name)
-	contents := strings.Join(*proj.Gitignore, "\n")
+	entries := append([]string{}, proj.GetGitignore()...)
+	if proj.IsCodeCov() {
+		entries = append(entries, "coverage.txt")
+	}
+	if proj.GetTestEnvFile() != "" && !Contains(entries, proj.GetTestEnvFile()) {
+		entries = append(entries, proj.GetTestEnvFile())
+	}
+	entries = append(entries, proj.GetName())
+	contents := strings.Join(entries, "\n")
 
-	err = ioutil.WriteFile(gitignorePath, []byte(contents), 0o644)
+	err = proj.writeFile(gitignorePath, []byte(contents))
 	if err != nil {
 		return err
 	}
@@ -428,13 +402,6 @@ func (proj *Project) SetGitignore() error {
 	return nil
 }
 
-func (proj *Project) AddCodeCov() error {
-	*proj.Gitignore = append(*proj.Gitignore, "coverage.txt")
-	*proj.GoTestArgs = append([]string{"-coverprofile=coverage.txt", "-covermode=atomic"}, *proj.GoTestArgs...)
-
-	return nil
-}
-
 func (proj *Project) CreateReadme() error {
 	pwd, err := os.Getwd()
 	if err != nil {
@@ -443,14 +410,12 @@ func (proj *Project) CreateReadme() error {
 
 	readmePath := fmt.Sprintf("%s/README.md", pwd)
 
-	if _, err := os.Stat(readmePath); errors.Is(err, os.ErrNotExist) {
-		c := `# ` + *proj.Name + `
+	c := `# ` + *proj.Name + `
 
 `
-		err := ioutil.WriteFile(readmePath, []byte(c+"\n"), 0o644)
-		if err != nil {
-			return err
-		}
+	err = proj.createFile(readmePath, []byte(c+"\n"))
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -464,16 +429,9 @@ func (proj *Project) SetCodeOwners() error {
 
 	path := fmt.Sprintf("%s/.github/CODEOWNERS", pwd)
 
-	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
-		err = os.MkdirAll(fmt.Sprintf("%s/.github", pwd), 0o755)
-		if err != nil {
-			return err
-		}
-	}
-
 	contents := strings.Join(*proj.CodeOwners, "\n")
 
-	err = ioutil.WriteFile(path, []byte(contents), 0o644)
+	err = proj.writeFile(path, []byte(contents))
 	if err != nil {
 		return err
 	}
@@ -482,19 +440,16 @@ func (proj *Project) SetCodeOwners() error {
 }
 
 func (proj *Project) RunLinter() error {
-	LogInfo(os.Stdout, "running go linter", "Lint")
-
-	lint := exec.Command("golangci-lint", "run")
-	lint.Stdout = os.Stdout
-	lint.Stderr = os.Stderr
+	LogInfo(proj.stdout(), "running go linter", "Lint")
 
-	err := lint.Run()
+	lint := proj.command("golangci-lint", "run")
+	err := proj.run(lint)
 	if err != nil {
-		LogFail(os.Stderr, "running golint failed", "Lint")
-		return errors.New("logged to stderr")
+		LogFail(proj.stderr(), "running golint failed", "Lint")
+		return &StageError{Stage: StageLint, Err: err}
 	}
 
-	LogSuccess(os.Stdout, "go linter passed", "Lint")
+	LogSuccess(proj.stdout(), "go linter passed", "Lint")
 	return nil
 }
 
@@ -516,10 +471,17 @@ func (proj *Project) getCommonSteps() []*github.JobStep {
 	if proj.IsIsGojen() {
 		wf = append(wf, &github.JobStep{
 			Name: String("Build and run gojen"),
-			Run:  String("go build && ./gojen --ci"),
-			Env:  proj.GetWorkflowEnv(),
+			Run:  String("go build && ./gojen run default --ci"),
+			Env:  proj.workflowEnv(),
 		})
 	} else {
+		// a pinned gojenVersion can predate gojen run, which those versions
+		// fail on as an unknown command
+		run := "gojen run default --ci"
+		if proj.GetGojenVersion() != "latest" {
+			run = "if gojen run --help > /dev/null 2>&1; then gojen run default --ci; else gojen --ci; fi"
+		}
+
 		wf = append(wf, &github.JobStep{
 			Name: String("Install gojen"),
 			Run: String(fmt.Sprintf("go install github.com/Hunter-Thompson/gojen@%s",
@@ -527,8 +489,8 @@ func (proj *Project) getCommonSteps() []*github.JobStep {
 		})
 		wf = append(wf, &github.JobStep{
 			Name: String("Run gojen"),
-			Run:  String("gojen --ci"),
-			Env:  proj.GetWorkflowEnv(),
+			Run:  String(run),
+			Env:  proj.workflowEnv(),
 		})
 	}
 
@@ -607,11 +569,6 @@ func (proj *Project) CreateReleaseWorkflow() error {
 		return err
 	}
 
-	err = os.MkdirAll(fmt.Sprintf("%s/.github/workflows", pwd), 0o755)
-	if err != nil {
-		return err
-	}
-
 	wf := github.CreateWorkflow("release")
 
 	wf.AddTrigger(github.Triggers{
@@ -668,11 +625,17 @@ func (proj *Project) CreateReleaseWorkflow() error {
 		return err
 	}
 
-	err = ioutil.WriteFile(fmt.Sprintf("%s/.github/workflows/release.yml", pwd), yaml, 0o644)
+	err = proj.writeFile(fmt.Sprintf("%s/.github/workflows/release.yml", pwd), yaml)
 	if err != nil {
 		return err
 	}
 
+	// libraries have no binary to upload, the workflow is left over from
+	// another preset
+	if proj.GetPreset() == PresetLibrary {
+		return proj.removeFile(fmt.Sprintf("%s/.github/workflows/upload-binary.yml", pwd))
+	}
+
 	wf2 := github.CreateWorkflow("Upload Binary")
 
 	wf2.AddTrigger(github.Triggers{
@@ -709,7 +672,7 @@ func (proj *Project) CreateReleaseWorkflow() error {
 		return err
 	}
 
-	err = ioutil.WriteFile(fmt.Sprintf("%s/.github/workflows/upload-binary.yml", pwd), yaml2, 0o644)
+	err = proj.writeFile(fmt.Sprintf("%s/.github/workflows/upload-binary.yml", pwd), yaml2)
 	if err != nil {
 		return err
 	}
@@ -723,11 +686,6 @@ func (proj *Project) CreateBuildWorkflow() error {
 		return err
 	}
 
-	err = os.MkdirAll(fmt.Sprintf("%s/.github/workflows", pwd), 0o755)
-	if err != nil {
-		return err
-	}
-
 	wf := github.CreateWorkflow("build")
 
 	wf.AddTrigger(github.Triggers{
@@ -780,7 +738,7 @@ HEAD:${{ github.event.pull_request.head.ref }}'`),
 		return err
 	}
 
-	err = ioutil.WriteFile(fmt.Sprintf("%s/.github/workflows/build.yml", pwd), yaml, 0o644)
+	err = proj.writeFile(fmt.Sprintf("%s/.github/workflows/build.yml", pwd), yaml)
 	if err != nil {
 		return err
 	}
@@ -936,6 +894,13 @@ func (proj *Project) GetTestEnvVars() []string {
 	return *proj.TestEnvVars
 }
 
+func (proj *Project) GetTestEnvFile() string {
+	if proj.TestEnvFile == nil {
+		return ""
+	}
+	return *proj.TestEnvFile
+}
+
 func (proj *Project) GetGoBuildArgs() []string {
 	if proj.GoBuildArgs == nil {
 		return []string{}
@@ -952,11 +917,25 @@ func (proj *Project) GetLicense() string {
 
 func (proj *Project) IsGoBuild() bool {
 	if proj.GoBuild == nil {
-		return true
+		return proj.GetPreset() != PresetLibrary
 	}
 	return *proj.GoBuild
 }
 
+func (proj *Project) GetTasks() map[string]*TaskConfig {
+	if proj.Tasks == nil {
+		return map[string]*TaskConfig{}
+	}
+	return *proj.Tasks
+}
+
+func (proj *Project) GetPreset() string {
+	if proj.Preset == nil {
+		return ""
+	}
+	return *proj.Preset
+}
+
 func (proj *Project) GetWorkflowEnv() *map[string]*string {
 	if proj.WorkflowEnv == nil {
 		return &map[string]*string{}
@@ -980,6 +959,17 @@ func StringSlice(s []string) *[]string {
 	return &s
 }
 
+// Licenses returns the SPDX ids of the license texts bundled with gojen.
+func Licenses() []string {
+	licenses := []string{}
+	for _, name := range license.AssetNames() {
+		licenses = append(licenses, strings.TrimSuffix(strings.TrimPrefix(name, "license-text/"), ".txt"))
+	}
+	sort.Strings(licenses)
+
+	return licenses
+}
+
 func Contains(s []string, e string) bool {
 	for _, a := range s {
 		if a == e {
@@ -1,6 +1,7 @@
 package project_test
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -70,7 +71,7 @@ func TestSProject(t *testing.T) {
 			AuthorEmail:          project.String("test1"),
 			AuthorOrganization:   project.String("test1"),
 			Release:              project.Bool(false),
-			License:              project.String("MIT-2.0"),
+			License:              project.String("MIT"),
 			DefaultReleaseBranch: project.String("test1"),
 			Gitignore:            project.StringSlice([]string{"test1", "test1"}),
 			CodeOwners:           project.StringSlice([]string{"test1", "test1"}),
@@ -290,7 +291,8 @@ func TestSProject(t *testing.T) {
 			err = createdProject.SetupProject()
 			if err != nil {
 				if k == 1 {
-					if err.Error() == "logged to stderr" {
+					var stageErr *project.StageError
+					if errors.As(err, &stageErr) && stageErr.Stage == project.StageSetup {
 						err = os.Chdir(pwd)
 						if err != nil {
 							t.Error(err.Error())
@@ -403,6 +405,11 @@ func TestFProject(t *testing.T) {
 			GoTest:               project.Bool(false),
 			GoTestArgs:           project.StringSlice([]string{"-v", "-cover", "./..."}),
 		},
+		{
+			Name:       project.String("test6"),
+			Repository: project.String("github.com/test/test6"),
+			License:    project.String("MIT-2.0"),
+		},
 	}
 
 	for k, p := range failedProjects {
@@ -413,10 +420,7 @@ func TestFProject(t *testing.T) {
 				t.Error(err.Error())
 			}
 
-			err = os.Chdir(dir)
-			if err != nil {
-				t.Error(err.Error())
-			}
+			chdir(t, dir)
 
 			err = p.WriteConfig()
 			if err != nil {
@@ -0,0 +1,170 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/Hunter-Thompson/gojen/pkg/github"
+	"gopkg.in/yaml.v3"
+)
+
+// Sources of a config value.
+const (
+	SourceFile     = "file"
+	SourceDefault  = "default"
+	SourceOverride = "override"
+)
+
+// Setting is a single value of the config.
+type Setting struct {
+	// Path is the JSON path of the value, e.g. workflowEnv.FOO.
+	Path string
+	// Value is the value as it would be written in YAML.
+	Value string
+	// Source is one of SourceFile, SourceDefault or SourceOverride.
+	Source string
+	// File is the config file the value came from, if its source is a file.
+	File string
+}
+
+// Defaults returns a project with every field set to the value its accessor
+// returns when the field is not configured.
+func Defaults() *Project {
+	return PresetDefaults("")
+}
+
+// PresetDefaults returns the defaults of a project using preset.
+func PresetDefaults(preset string) *Project {
+	p := &Project{Preset: String(preset)}
+
+	return &Project{
+		Preset:               String(p.GetPreset()),
+		Name:                 String(p.GetName()),
+		Description:          String(p.GetDescription()),
+		Repository:           String(p.GetRepository()),
+		GoVersion:            String(p.GetGoVersion()),
+		AuthorName:           String(p.GetAuthorName()),
+		AuthorEmail:          String(p.GetAuthorEmail()),
+		AuthorOrganization:   String(p.GetAuthorOrganization()),
+		Readme:               Bool(p.IsCreateReadme()),
+		GojenVersion:         String(p.GetGojenVersion()),
+		License:              String(p.GetLicense()),
+		Release:              Bool(p.IsRelease()),
+		BuildWorkflow:        Bool(p.IsBuildWorkflow()),
+		GithubToken:          String(p.GetGitHubToken()),
+		DefaultReleaseBranch: String(p.GetDefaultReleaseBranch()),
+		IsGojen:              Bool(p.IsIsGojen()),
+		CodeCov:              Bool(p.IsCodeCov()),
+		TestEnvVars:          StringSlice(p.GetTestEnvVars()),
+		TestEnvFile:          String(p.GetTestEnvFile()),
+		Gitignore:            StringSlice(p.GetGitignore()),
+		CodeOwners:           StringSlice(p.GetCodeOwners()),
+		SkipVendor:           Bool(false),
+		SkipTidy:             Bool(false),
+		GoLinter:             Bool(p.IsGoLinter()),
+		GoTest:               Bool(p.IsGoTest()),
+		GoTestArgs:           StringSlice(p.GetGoTestArgs()),
+		GoBuild:              Bool(p.IsGoBuild()),
+		GoBuildArgs:          StringSlice(p.GetGoBuildArgs()),
+		WorkflowEnv:          p.GetWorkflowEnv(),
+		PrependSteps:         &[]*github.JobStep{},
+		AppendSteps:          &[]*github.JobStep{},
+		Tasks:                &map[string]*TaskConfig{},
+		Hooks:                &Hooks{},
+		Timeouts:             &map[string]*string{},
+	}
+}
+
+// Settings returns every scalar of the config as it was read, along with
+// where it came from, in the order they appear in the merged config.
+func (proj *Project) Settings() []Setting {
+	settings := []Setting{}
+	if proj.raw != nil {
+		proj.settings(proj.raw, "", &settings)
+	}
+
+	return settings
+}
+
+// Resolved returns every scalar of the effective config, including the
+// defaults of fields that are not configured, in the order of the fields of
+// Project.
+func (proj *Project) Resolved() ([]Setting, error) {
+	defaults := &yaml.Node{}
+	err := defaults.Encode(PresetDefaults(proj.GetPreset()))
+	if err != nil {
+		return nil, err
+	}
+
+	settings := []Setting{}
+	for i := 0; i+1 < len(defaults.Content); i += 2 {
+		key := defaults.Content[i].Value
+
+		if proj.raw != nil {
+			if value := mappingValue(proj.raw, key); value != nil {
+				proj.settings(value, key, &settings)
+				continue
+			}
+		}
+
+		if value := defaults.Content[i+1]; value.Tag != "!!null" {
+			collectSettings(value, key, func(path string) Setting {
+				return Setting{Path: path, Source: SourceDefault}
+			}, &settings)
+		}
+	}
+
+	// keys that are not fields of Project are still shown, ValidateConfig
+	// reports them as unknown
+	if proj.raw != nil {
+		for i := 0; i+1 < len(proj.raw.Content); i += 2 {
+			if mappingValue(defaults, proj.raw.Content[i].Value) == nil {
+				proj.settings(proj.raw.Content[i+1], proj.raw.Content[i].Value, &settings)
+			}
+		}
+	}
+
+	return settings, nil
+}
+
+func (proj *Project) settings(node *yaml.Node, path string, settings *[]Setting) {
+	collectSettings(node, path, func(path string) Setting {
+		file := proj.fileOf(path)
+		if file == overrideOrigin {
+			return Setting{Path: path, Source: SourceOverride}
+		}
+
+		return Setting{Path: path, Source: SourceFile, File: file}
+	}, settings)
+}
+
+// fileOf returns the config file the value at path, or its closest parent,
+// came from.
+func (proj *Project) fileOf(path string) string {
+	return originOf(proj.origins, path)
+}
+
+// collectSettings appends a setting for every scalar below node, along with
+// empty lists and objects.
+func collectSettings(node *yaml.Node, path string, setting func(path string) Setting, settings *[]Setting) {
+	switch {
+	case node.Kind == yaml.MappingNode && len(node.Content) > 0:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			collectSettings(node.Content[i+1], joinPath(path, node.Content[i].Value), setting, settings)
+		}
+	case node.Kind == yaml.SequenceNode && len(node.Content) > 0:
+		for i, item := range node.Content {
+			collectSettings(item, fmt.Sprintf("%s[%d]", path, i), setting, settings)
+		}
+	default:
+		s := setting(path)
+		s.Value = node.Value
+		switch node.Kind {
+		case yaml.MappingNode:
+			s.Value = "{}"
+		case yaml.SequenceNode:
+			s.Value = "[]"
+		}
+
+		*settings = append(*settings, s)
+	}
+}
@@ -0,0 +1,102 @@
+package project_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestDefaults(t *testing.T) {
+	d := reflect.ValueOf(project.Defaults()).Elem()
+
+	for i := 0; i < d.NumField(); i++ {
+		f := d.Type().Field(i)
+		if f.PkgPath != "" || f.Name == "Schema" || f.Name == "Extends" {
+			continue
+		}
+
+		if d.Field(i).IsNil() {
+			t.Errorf("expected Defaults to set %s", f.Name)
+		}
+	}
+}
+
+func TestResolved(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	writeFiles(t, dir, map[string]string{
+		"gojen.yaml": "name: test\nrepository: github.com/test/test\ngoVersion: \"1.17\"\ngitignore: [dist]\n",
+	})
+
+	project.Overrides = []string{"goTest=false", "workflowEnv.FOO=bar", "gitignore[1]=.idea"}
+	t.Cleanup(func() {
+		project.Overrides = nil
+	})
+
+	proj, err := project.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = proj.ValidateConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proj.IsGoTest() {
+		t.Error("expected goTest to be overridden")
+	}
+
+	settings, err := proj.Resolved()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]project.Setting{}
+	for _, s := range settings {
+		got[s.Path] = s
+	}
+
+	cfg := filepath.Join(dir, "gojen.yaml")
+	want := []project.Setting{
+		{Path: "name", Value: "test", Source: project.SourceFile, File: cfg},
+		{Path: "goVersion", Value: "1.17", Source: project.SourceFile, File: cfg},
+		{Path: "gojenVersion", Value: "latest", Source: project.SourceDefault},
+		{Path: "goBuild", Value: "true", Source: project.SourceDefault},
+		{Path: "goTest", Value: "false", Source: project.SourceOverride},
+		{Path: "gitignore[0]", Value: "dist", Source: project.SourceFile, File: cfg},
+		{Path: "gitignore[1]", Value: ".idea", Source: project.SourceOverride},
+		{Path: "workflowEnv.FOO", Value: "bar", Source: project.SourceOverride},
+		{Path: "codeOwners", Value: "[]", Source: project.SourceDefault},
+	}
+	for _, w := range want {
+		if got[w.Path] != w {
+			t.Errorf("expected %+v, got %+v", w, got[w.Path])
+		}
+	}
+}
+
+func TestOverridesErrors(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	writeFiles(t, dir, map[string]string{
+		"gojen.json": `{"name": "test", "gitignore": ["dist"]}`,
+	})
+
+	t.Cleanup(func() {
+		project.Overrides = nil
+	})
+
+	for _, o := range []string{"goTest", "name.first=x", "gitignore[5]=x"} {
+		project.Overrides = []string{o}
+
+		_, err := project.GetConfig()
+		if err == nil {
+			t.Errorf("expected --set %s to fail", o)
+		}
+	}
+}
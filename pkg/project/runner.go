@@ -0,0 +1,187 @@
+package project
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Command is a command run by gojen, such as go test.
+type Command struct {
+	Name string
+	Args []string
+	// Dir is the directory the command runs in.
+	Dir string
+	// Env holds KEY=value pairs added to the environment of gojen.
+	Env    []string
+	Stdout io.Writer
+	Stderr io.Writer
+	// Context is done once the command must stop, as the stage it runs for
+	// timed out or gojen was interrupted, see SetContext. A Runner must stop
+	// the command and return when it is done. It is nil for commands that
+	// are not stopped early.
+	Context context.Context
+}
+
+// String returns the command the way it would be typed in a shell.
+func (c *Command) String() string {
+	return formatCommand(append([]string{c.Name}, c.Args...))
+}
+
+// Runner runs the commands of a project. Use SetRunner to replace the
+// default, which runs them using os/exec. Runners must stop a command once
+// its Context is done, for timeouts and interrupts to work.
+type Runner interface {
+	Run(c *Command) error
+}
+
+// ExecRunner runs commands using os/exec.
+type ExecRunner struct{}
+
+// killGracePeriod is how long the processes of a command that must stop have
+// after SIGTERM before they are killed.
+var killGracePeriod = 5 * time.Second
+
+// Run runs c and waits for it to finish. The command runs in a process group
+// of its own, which is sent SIGTERM when the stage it runs for times out or
+// gojen is interrupted, and killed once the command exits or killGracePeriod
+// passes, so no process it started is left behind.
+func (ExecRunner) Run(c *Command) error {
+	cmd := exec.Command(c.Name, c.Args...)
+	cmd.Dir = c.Dir
+	cmd.Stdout = c.Stdout
+	cmd.Stderr = c.Stderr
+	if len(c.Env) > 0 {
+		cmd.Env = append(os.Environ(), c.Env...)
+	}
+	setProcessGroup(cmd)
+
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	ctx := c.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		// commands such as docker compose up get to clean up, what is left
+		// of them after killGracePeriod is killed
+		terminateProcessGroup(cmd)
+		select {
+		case <-done:
+			killProcessGroup(cmd)
+		case <-time.After(killGracePeriod):
+			killProcessGroup(cmd)
+			<-done
+		}
+		return ctx.Err()
+	}
+}
+
+// RecordingRunner records the commands it is given instead of running them,
+// for tests.
+type RecordingRunner struct {
+	Commands []*Command
+	// Err, when set, returns the result of running a command.
+	Err func(c *Command) error
+
+	mu sync.Mutex
+}
+
+// Run records c, returning the result of Err.
+func (r *RecordingRunner) Run(c *Command) error {
+	r.mu.Lock()
+	r.Commands = append(r.Commands, c)
+	r.mu.Unlock()
+
+	if r.Err != nil {
+		return r.Err(c)
+	}
+
+	return nil
+}
+
+// Strings returns every recorded command formatted by Command.String.
+func (r *RecordingRunner) Strings() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := make([]string, 0, len(r.Commands))
+	for _, c := range r.Commands {
+		s = append(s, c.String())
+	}
+
+	return s
+}
+
+// SetRunner makes the project run its commands using r.
+func (proj *Project) SetRunner(r Runner) {
+	proj.runner = r
+}
+
+// command returns the command name with args, writing to the output of the
+// project and running in the working directory.
+func (proj *Project) command(name string, args ...string) *Command {
+	// an empty Dir runs in the working directory all the same
+	dir, _ := os.Getwd()
+
+	return &Command{
+		Name:    name,
+		Args:    args,
+		Dir:     dir,
+		Stdout:  proj.stdout(),
+		Stderr:  proj.stderr(),
+		Context: proj.ctx,
+	}
+}
+
+// stdout returns where the project writes its output, os.Stdout unless it
+// is captured.
+func (proj *Project) stdout() io.Writer {
+	if proj.out == nil {
+		return os.Stdout
+	}
+
+	return proj.out
+}
+
+// stderr returns where the project writes its errors, os.Stderr unless they
+// are captured.
+func (proj *Project) stderr() io.Writer {
+	if proj.errOut == nil {
+		return os.Stderr
+	}
+
+	return proj.errOut
+}
+
+// run runs c using the runner of the project, in a dry run the command is
+// only reported.
+func (proj *Project) run(c *Command) error {
+	if DryRun {
+		printPlan("run", c.String())
+		return nil
+	}
+
+	proj.emit(&Event{Event: EventCommand, Command: c.String(), Dir: c.Dir})
+
+	if proj.runner == nil {
+		return ExecRunner{}.Run(c)
+	}
+
+	return proj.runner.Run(c)
+}
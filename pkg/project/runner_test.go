@@ -0,0 +1,78 @@
+package project_test
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestRunner(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	p := &project.Project{
+		Name:        project.String("test"),
+		Repository:  project.String("github.com/test/test"),
+		CodeCov:     project.Bool(true),
+		GoTestArgs:  project.StringSlice([]string{"-v", "./..."}),
+		GoBuildArgs: project.StringSlice([]string{"-o", "bin/test"}),
+		Tasks: &map[string]*project.TaskConfig{
+			"up": {
+				DependsOn: project.StringSlice([]string{"test"}),
+				Steps:     project.StringSlice([]string{"docker compose up -d"}),
+			},
+		},
+	}
+
+	runner := &project.RecordingRunner{}
+	p.SetRunner(runner)
+
+	err := p.RunTask("up")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"go mod init github.com/test/test",
+		"go mod vendor",
+		"go mod tidy",
+		"go fmt ./...",
+		"go test -coverprofile=coverage.txt -covermode=atomic -v ./...",
+		"sh -c 'docker compose up -d'",
+	}
+	if !reflect.DeepEqual(runner.Strings(), expected) {
+		t.Errorf("expected commands %q, got %q", expected, runner.Strings())
+	}
+
+	for _, c := range runner.Commands {
+		// the temp dir may be behind a symlink, e.g. on macOS
+		want, _ := filepath.EvalSymlinks(dir)
+		got, _ := filepath.EvalSymlinks(c.Dir)
+		if got != want {
+			t.Errorf("expected %s to run in %s, got %s", c, dir, c.Dir)
+		}
+	}
+
+	runner = &project.RecordingRunner{
+		Err: func(c *project.Command) error {
+			if c.Name == "go" && c.Args[0] == "build" {
+				return errors.New("exit status 2")
+			}
+			return nil
+		},
+	}
+	p.SetRunner(runner)
+
+	err = p.RunBuild()
+	if err == nil {
+		t.Error("expected a failing go build to fail")
+	}
+
+	last := runner.Commands[len(runner.Commands)-1]
+	if last.String() != "go build -o bin/test" {
+		t.Errorf("expected go build to get goBuildArgs, got %s", last)
+	}
+}
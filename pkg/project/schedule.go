@@ -0,0 +1,266 @@
+package project
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// Parallel runs the tasks that do not depend on each other at the same time,
+// up to Jobs at once. The output of every task is buffered until it finishes,
+// so the output of tasks does not interleave. Dry runs are never parallel.
+var (
+	Parallel bool
+	Jobs     = runtime.NumCPU()
+)
+
+// RunAll keeps running the tasks that do not depend on a failed task, instead
+// of stopping at the first failure.
+var RunAll bool
+
+// stageRun is the outcome of a task run on a copy of the project.
+type stageRun struct {
+	task   *Task
+	proj   *Project
+	result *StageResult
+	err    error
+	// stdout and stderr hold the output of a buffered task.
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+}
+
+// RunTask runs the task called name after the tasks it depends on, every task
+// runs at most once.
+func (proj *Project) RunTask(name string) error {
+	order, err := proj.taskOrder(name)
+	if err != nil {
+		return err
+	}
+
+	err = checkSelection(name, order)
+	if err != nil {
+		return err
+	}
+
+	if Output == OutputJSON && proj.outputDir == "" {
+		proj.outputDir, err = ioutil.TempDir("", "gojen-output")
+		if err != nil {
+			return err
+		}
+	}
+
+	jobs := 1
+	if Parallel && !DryRun && Jobs > 1 {
+		jobs = Jobs
+	}
+
+	inOrder := map[string]int{}
+	for i, t := range order {
+		inOrder[t.Name] = i
+	}
+
+	proj.results = nil
+	started := map[string]bool{}
+	done := map[string]bool{}
+	// blocked maps the tasks that failed, or depend on a task that did, to
+	// the task that failed
+	blocked := map[string]string{}
+	finished := make(chan *stageRun)
+	running := 0
+
+	// the first failure is returned, failed checks do not stop the run so
+	// every problem is reported
+	var failed, checkFailed error
+	var failedTask string
+
+	for {
+		for _, t := range order {
+			if started[t.Name] {
+				continue
+			}
+
+			if proj.context().Err() != nil {
+				started[t.Name] = true
+				proj.skipStage(t, "not run, interrupted")
+				continue
+			}
+
+			if failed != nil && !RunAll {
+				started[t.Name] = true
+				proj.skipStage(t, "not run, "+failedTask+" failed")
+				continue
+			}
+
+			if !t.hasWork() {
+				ready := true
+				for _, d := range t.DependsOn {
+					ready = ready && done[d]
+				}
+				if ready {
+					// a task grouping others is blocked by the failures of
+					// the tasks it groups, to pass on to the tasks after it
+					for _, d := range t.DependsOn {
+						if b, ok := blocked[d]; ok {
+							blocked[t.Name] = b
+						}
+					}
+					started[t.Name] = true
+					done[t.Name] = true
+				}
+				continue
+			}
+
+			ready := true
+			by := ""
+			for _, d := range t.DependsOn {
+				if b, ok := blocked[d]; ok {
+					by = b
+				}
+				if !done[d] {
+					ready = false
+				}
+			}
+			for _, a := range t.after {
+				if _, ok := inOrder[a]; ok && !done[a] {
+					ready = false
+				}
+			}
+
+			if by != "" {
+				started[t.Name] = true
+				done[t.Name] = true
+				blocked[t.Name] = by
+				proj.skipStage(t, "not run, "+by+" failed")
+				continue
+			}
+
+			if !ready {
+				continue
+			}
+
+			if reason := proj.skipReason(t); reason != "" {
+				started[t.Name] = true
+				done[t.Name] = true
+				proj.skipStage(t, reason)
+				continue
+			}
+
+			if running >= jobs {
+				break
+			}
+
+			started[t.Name] = true
+			running++
+
+			// every stage runs on its own copy of the project, so they can
+			// run at the same time
+			stage := *proj
+			stage.changes = nil
+			go func(t *Task, stage *Project) {
+				finished <- stage.runStage(t, jobs > 1)
+			}(t, &stage)
+
+			if jobs == 1 {
+				break
+			}
+		}
+
+		if running == 0 {
+			break
+		}
+
+		r := <-finished
+		running--
+		done[r.task.Name] = true
+		proj.changes = append(proj.changes, r.proj.changes...)
+		proj.results = append(proj.results, r.result)
+
+		if r.stdout != nil {
+			_, _ = proj.stdout().Write(r.stdout.Bytes())
+			_, _ = proj.stderr().Write(r.stderr.Bytes())
+		}
+
+		switch {
+		case r.err == nil:
+		case errors.Is(r.err, errCheckFailed):
+			if checkFailed == nil {
+				checkFailed = r.err
+			}
+		default:
+			blocked[r.task.Name] = r.task.Name
+			if failed == nil {
+				failed = r.err
+				failedTask = r.task.Name
+			}
+		}
+	}
+
+	sort.SliceStable(proj.results, func(i, j int) bool {
+		return inOrder[proj.results[i].Task] < inOrder[proj.results[j].Task]
+	})
+
+	if failed != nil {
+		return failed
+	}
+
+	return checkFailed
+}
+
+// runStage runs t as a stage of the run on the copy of the project made for
+// it, reporting when it starts and finishes. With buffered set the output of
+// the task is kept in the returned stageRun.
+func (proj *Project) runStage(t *Task, buffered bool) *stageRun {
+	proj.task = t.Name
+
+	r := &stageRun{task: t, proj: proj}
+	if buffered && Output == OutputText {
+		r.stdout, r.stderr = &bytes.Buffer{}, &bytes.Buffer{}
+		proj.out, proj.errOut = r.stdout, r.stderr
+	}
+
+	output, stop, err := proj.startCapture(t)
+	if err != nil {
+		r.err = err
+		r.result = &StageResult{Task: t.Name, Stage: t.Stage, Status: StatusFailed, Start: time.Now()}
+		return r
+	}
+
+	ctx, cancel := proj.stageContext(t)
+	defer cancel()
+	proj.ctx = ctx
+
+	proj.emit(&Event{Event: EventStageStarted, Stage: t.Stage})
+	start := time.Now()
+
+	r.err = stageError(t.Stage, t.Name, proj.stageFailure(ctx, t, proj.runTask(t)))
+	stop()
+
+	d := time.Since(start)
+	proj.emit(finishedEvent(t, d, r.err, output))
+
+	r.result = &StageResult{Task: t.Name, Stage: t.Stage, Status: StatusPassed, Start: start, Duration: d}
+	if r.err != nil {
+		r.result.Status = StatusFailed
+	} else {
+		proj.storeCache(t)
+	}
+
+	return r
+}
+
+// skipStage reports that t was skipped for reason.
+func (proj *Project) skipStage(t *Task, reason string) {
+	if !t.hasWork() {
+		return
+	}
+
+	if DryRun {
+		printPlan("skip", t.Name+": "+reason)
+	}
+
+	proj.emit(&Event{Event: EventStageSkipped, Task: t.Name, Stage: t.Stage, Reason: reason})
+	proj.results = append(proj.results, &StageResult{Task: t.Name, Stage: t.Stage, Status: StatusSkipped, Reason: reason})
+}
@@ -0,0 +1,171 @@
+package project_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestParallel(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	project.Parallel = true
+	project.Jobs = 2
+	t.Cleanup(func() {
+		project.Parallel = false
+		project.Jobs = runtime.NumCPU()
+		project.RunAll = false
+	})
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Tasks: &map[string]*project.TaskConfig{
+			"a":     {Steps: project.StringSlice([]string{"a"})},
+			"b":     {Steps: project.StringSlice([]string{"b"})},
+			"c":     {Steps: project.StringSlice([]string{"c"})},
+			"d":     {DependsOn: project.StringSlice([]string{"a"}), Steps: project.StringSlice([]string{"d"})},
+			"all":   {DependsOn: project.StringSlice([]string{"a", "b", "c", "d"})},
+			"group": {DependsOn: project.StringSlice([]string{"a"})},
+			"x":     {DependsOn: project.StringSlice([]string{"group"}), Steps: project.StringSlice([]string{"x"})},
+		},
+	}
+
+	var mu sync.Mutex
+	running, most := 0, 0
+	fail := ""
+	p.SetRunner(&project.RecordingRunner{
+		Err: func(c *project.Command) error {
+			mu.Lock()
+			running++
+			if running > most {
+				most = running
+			}
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+
+			if c.Args[1] == fail {
+				return errors.New("exit status 1")
+			}
+			return nil
+		},
+	})
+
+	err := p.RunTask("all")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if most != 2 {
+		t.Errorf("expected 2 stages to run at the same time, got %d", most)
+	}
+
+	statuses := func() string {
+		s := []string{}
+		for _, r := range p.Results() {
+			s = append(s, r.Task+" "+r.Status+" "+r.Reason)
+		}
+		return strings.Join(s, ",")
+	}
+
+	if statuses() != "a passed ,b passed ,c passed ,d passed " {
+		t.Errorf("expected every stage to pass in order, got %s", statuses())
+	}
+
+	// run-all keeps running the stages that do not depend on a
+	project.RunAll = true
+	fail = "a"
+	err = p.RunTask("all")
+	if err == nil {
+		t.Fatal("expected a to fail")
+	}
+
+	if statuses() != "a failed ,b passed ,c passed ,d skipped not run, a failed" {
+		t.Errorf("expected only d to be skipped, got %s", statuses())
+	}
+
+	// a task depending on a failed task through a task without steps is
+	// skipped all the same
+	err = p.RunTask("x")
+	if err == nil {
+		t.Fatal("expected a to fail")
+	}
+
+	if statuses() != "a failed ,x skipped not run, a failed" {
+		t.Errorf("expected x to be skipped, got %s", statuses())
+	}
+
+	// fail fast stops at the first failure
+	project.RunAll = false
+	project.Parallel = false
+	err = p.RunTask("all")
+	if err == nil {
+		t.Fatal("expected a to fail")
+	}
+
+	if statuses() != "a failed ,b skipped not run, a failed,c skipped not run, a failed,d skipped not run, a failed" {
+		t.Errorf("expected every stage after a to be skipped, got %s", statuses())
+	}
+}
+
+func TestParallelOutput(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	project.Parallel = true
+	project.Jobs = 2
+	t.Cleanup(func() {
+		project.Parallel = false
+		project.Jobs = runtime.NumCPU()
+	})
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Tasks: &map[string]*project.TaskConfig{
+			"a":   {Steps: project.StringSlice([]string{"a"})},
+			"b":   {Steps: project.StringSlice([]string{"b"})},
+			"all": {DependsOn: project.StringSlice([]string{"a", "b"})},
+		},
+	}
+
+	p.SetRunner(&project.RecordingRunner{
+		Err: func(c *project.Command) error {
+			fmt.Fprintf(c.Stdout, "stdout of %s\n", c.Args[len(c.Args)-1])
+			fmt.Fprintf(c.Stderr, "stderr of %s\n", c.Args[len(c.Args)-1])
+			if strings.HasSuffix(c.String(), "b") {
+				return errors.New("exit status 1")
+			}
+			return nil
+		},
+	})
+
+	// the output of the tasks is buffered, and then written where it would
+	// have been without --parallel
+	stdout, stderr := captureOutput(t, func() {
+		_ = p.RunTask("all")
+	})
+
+	for _, s := range []string{"stdout of a", "stdout of b"} {
+		if !strings.Contains(stdout, s) || strings.Contains(stderr, s) {
+			t.Errorf("expected %q on stdout only, got stdout:\n%s\nstderr:\n%s", s, stdout, stderr)
+		}
+	}
+	for _, s := range []string{"stderr of a", "stderr of b", "running b failed"} {
+		if !strings.Contains(stderr, s) || strings.Contains(stdout, s) {
+			t.Errorf("expected %q on stderr only, got stdout:\n%s\nstderr:\n%s", s, stdout, stderr)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package project
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/Hunter-Thompson/gojen/pkg/github"
+)
+
+// SchemaURL is where the JSON Schema of the gojen config is published, it is
+// written as the $schema of configs created by `gojen new`.
+const SchemaURL = "https://raw.githubusercontent.com/Hunter-Thompson/gojen/master/gojen.schema.json"
+
+var fieldDescriptions = map[string]string{
+	"$schema":              "JSON Schema used by editors to validate this file",
+	"extends":              "Config files this config inherits from, relative to this file",
+	"preset":               "Kind of project, one of cli, library or service, used for the scaffolded files and defaults",
+	"name":                 "Name of the project, also used as the name of the built binary",
+	"description":          "Description of the project",
+	"repository":           "Go module path of the project, e.g. github.com/Hunter-Thompson/gojen",
+	"goVersion":            "Go version used in the generated workflows",
+	"authorName":           "Name of the author",
+	"authorEmail":          "Email of the author",
+	"authorOrganization":   "GitHub organization of the author",
+	"readme":               "Create a README.md if it does not exist",
+	"gojenVersion":         "Version of gojen installed in the generated workflows, versions without gojen run are run as gojen --ci",
+	"license":              "SPDX id of the license written to LICENSE",
+	"release":              "Create the release and upload binary workflows",
+	"buildWorkflow":        "Create the pull request build workflow",
+	"githubToken":          "Name of the repository secret holding the GitHub token used by the workflows",
+	"defaultReleaseBranch": "Branch releases are created from",
+	"isGojen":              "Build gojen from source in the workflows, only used by gojen itself",
+	"codeCov":              "Collect test coverage and upload it to codecov",
+	"testEnvVars":          "Environment variables set when running go test, as KEY=value, or KEY to set it from the repository secret of the same name in the workflows",
+	"testEnvFile":          "Dotenv file of environment variables set when running go test locally, added to .gitignore",
+	"gitignore":            "Entries written to .gitignore",
+	"codeOwners":           "Entries written to .github/CODEOWNERS",
+	"skipVendor":           "Do not run go mod vendor",
+	"skipTidy":             "Do not run go mod tidy",
+	"goLinter":             "Run golangci-lint",
+	"goTest":               "Run go test",
+	"goTestArgs":           "Arguments passed to go test",
+	"goBuild":              "Run go build",
+	"goBuildArgs":          "Arguments passed to go build",
+	"workflowEnv":          "Environment variables set when running gojen in the workflows",
+	"prependSteps":         "Workflow steps added before gojen runs",
+	"apendSteps":           "Workflow steps added after gojen runs",
+	"tasks":                "Tasks run using gojen run <task>, keyed by name, with their steps and the tasks they depend on",
+	"hooks":                "Shell commands run before and after the built-in stages, keyed by pre or post and the stage, e.g. preTest or postBuild",
+	"timeouts":             "How long tasks may run before they are stopped and fail, keyed by task, e.g. test: 10m",
+}
+
+// FieldDescription returns the description of the config field stored under
+// key, e.g. goVersion.
+func FieldDescription(key string) string {
+	return fieldDescriptions[key]
+}
+
+// Schema returns the JSON Schema of the gojen config, generated from the
+// Project struct.
+func Schema() ([]byte, error) {
+	g := &schemaGenerator{
+		definitions: map[string]interface{}{},
+	}
+
+	schema := g.object(reflect.TypeOf(Project{}))
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["$id"] = SchemaURL
+	schema["title"] = "gojen config"
+	schema["required"] = []string{"name", "repository"}
+	schema["definitions"] = g.definitions
+
+	props := schema["properties"].(map[string]interface{})
+	props["license"].(map[string]interface{})["enum"] = append([]interface{}{nil, ""}, stringsToInterfaces(Licenses())...)
+	props["preset"].(map[string]interface{})["enum"] = append([]interface{}{nil, ""}, stringsToInterfaces(Presets())...)
+	props["goVersion"].(map[string]interface{})["pattern"] = goVersionRe.String()
+	props["githubToken"].(map[string]interface{})["pattern"] = secretNameRe.String()
+	props["workflowEnv"].(map[string]interface{})["propertyNames"] = map[string]interface{}{
+		"pattern": envVarNameRe.String(),
+	}
+
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+type schemaGenerator struct {
+	definitions map[string]interface{}
+}
+
+func (g *schemaGenerator) object(t reflect.Type) map[string]interface{} {
+	props := map[string]interface{}{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name, _ := fieldKey(f, FormatJSON)
+		prop := g.schema(f.Type)
+		if d, ok := fieldDescriptions[name]; ok && t == reflect.TypeOf(Project{}) {
+			prop["description"] = d
+		}
+
+		props[name] = prop
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) map[string]interface{} {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		nullable = true
+		t = t.Elem()
+	}
+
+	var s map[string]interface{}
+
+	switch t.Kind() {
+	case reflect.String:
+		s = map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		s = map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int64:
+		s = map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		s = map[string]interface{}{"type": "number"}
+	case reflect.Slice:
+		s = map[string]interface{}{
+			"type":  "array",
+			"items": g.schema(t.Elem()),
+		}
+		nullable = true
+	case reflect.Map:
+		s = map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": g.schema(t.Elem()),
+		}
+		nullable = true
+	case reflect.Struct:
+		if _, ok := g.definitions[t.Name()]; !ok {
+			g.definitions[t.Name()] = map[string]interface{}{}
+			def := g.object(t)
+			if t == reflect.TypeOf(github.JobStep{}) {
+				def["oneOf"] = []interface{}{
+					map[string]interface{}{"required": []string{"run"}},
+					map[string]interface{}{"required": []string{"uses"}},
+				}
+			}
+			g.definitions[t.Name()] = def
+		}
+
+		s = map[string]interface{}{"$ref": "#/definitions/" + t.Name()}
+	default:
+		return map[string]interface{}{}
+	}
+
+	if nullable {
+		if typ, ok := s["type"].(string); ok {
+			s["type"] = []string{typ, "null"}
+		} else {
+			s = map[string]interface{}{
+				"oneOf": []interface{}{s, map[string]interface{}{"type": "null"}},
+			}
+		}
+	}
+
+	return s
+}
+
+func stringsToInterfaces(s []string) []interface{} {
+	out := make([]interface{}, 0, len(s))
+	for _, v := range s {
+		out = append(out, v)
+	}
+
+	return out
+}
@@ -0,0 +1,38 @@
+package project_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestSchema(t *testing.T) {
+	b, err := project.Schema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	committed, err := ioutil.ReadFile(filepath.Join("..", "..", "gojen.schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != string(committed) {
+		t.Error("gojen.schema.json is out of date, regenerate it using\n\n$ go run . schema > gojen.schema.json")
+	}
+
+	schema := map[string]interface{}{}
+	err = json.Unmarshal(b, &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, prop := range schema["properties"].(map[string]interface{}) {
+		if _, ok := prop.(map[string]interface{})["description"]; !ok {
+			t.Errorf("expected %s to have a description", name)
+		}
+	}
+}
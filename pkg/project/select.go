@@ -0,0 +1,68 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Only and Skip select the stages of a run, overriding the config. With Only
+// set, the tasks not listed are skipped, while the ones listed run even when
+// the config skips them. The tasks listed in Skip are skipped.
+var (
+	Only []string
+	Skip []string
+)
+
+// notSelectedReason is why the tasks Only leaves out are skipped.
+const notSelectedReason = "not selected with --only"
+
+// skipReason returns why t is skipped in this run, if it is.
+func (proj *Project) skipReason(t *Task) string {
+	if Contains(Skip, t.Name) {
+		return "skipped with --skip"
+	}
+
+	if len(Only) > 0 && !Contains(Only, t.Name) {
+		return notSelectedReason
+	}
+
+	if len(Only) == 0 && t.skip != nil {
+		if reason := t.skip(proj); reason != "" {
+			return reason
+		}
+	}
+
+	if proj.cached(t) {
+		// with OutputJSON the stageSkipped event tells why
+		if Output == OutputText {
+			LogInfo(proj.stdout(), "skipped, "+cachedReason, t.Name)
+		}
+		return cachedReason
+	}
+
+	return ""
+}
+
+// checkSelection fails when Only or Skip list a stage that is not part of
+// running the task called name.
+func checkSelection(name string, order []*Task) error {
+	stages := []string{}
+	for _, t := range order {
+		if t.hasWork() {
+			stages = append(stages, t.Name)
+		}
+	}
+
+	for _, flag := range []struct {
+		name  string
+		names []string
+	}{{"--only", Only}, {"--skip", Skip}} {
+		for _, n := range flag.names {
+			if !Contains(stages, n) {
+				return fmt.Errorf("%s: unknown stage %q, task %s runs %s", flag.name, n, name, strings.Join(stages, ", "))
+			}
+		}
+	}
+
+	return nil
+}
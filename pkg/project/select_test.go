@@ -0,0 +1,71 @@
+package project_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestSelectStages(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+	t.Cleanup(func() {
+		project.Only = nil
+		project.Skip = nil
+	})
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		GoTest:     project.Bool(false),
+	}
+
+	runner := &project.RecordingRunner{}
+	p.SetRunner(runner)
+
+	project.Skip = []string{"vendor", "tidy", "build"}
+	err := p.RunTask(project.DefaultTask)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"go mod init github.com/test/test", "go fmt ./..."}
+	if !reflect.DeepEqual(runner.Strings(), expected) {
+		t.Errorf("expected commands %q, got %q", expected, runner.Strings())
+	}
+
+	for _, r := range p.Results() {
+		if r.Task == "vendor" && r.Reason != "skipped with --skip" {
+			t.Errorf("expected vendor to be skipped with --skip, got %q", r.Reason)
+		}
+	}
+
+	// --only runs a stage the config skips
+	runner.Commands = nil
+	project.Skip = nil
+	project.Only = []string{"test"}
+	err = p.RunTask(project.DefaultTask)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = []string{"go test"}
+	if !reflect.DeepEqual(runner.Strings(), expected) {
+		t.Errorf("expected commands %q, got %q", expected, runner.Strings())
+	}
+
+	project.Only = []string{"tests"}
+	err = p.RunTask(project.DefaultTask)
+	if err == nil || !strings.Contains(err.Error(), `--only: unknown stage "tests"`) {
+		t.Errorf("expected an unknown stage to be rejected, got %v", err)
+	}
+
+	project.Only = nil
+	project.Skip = []string{"build"}
+	err = p.RunTask("test")
+	if err == nil {
+		t.Error("expected a stage that task test does not run to be rejected")
+	}
+}
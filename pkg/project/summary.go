@@ -0,0 +1,67 @@
+package project
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// StageResult is the outcome of a task run by RunTask.
+type StageResult struct {
+	Task  string
+	Stage string
+	// Status is StatusPassed, StatusFailed or StatusSkipped.
+	Status string
+	// Reason is why the task was skipped.
+	Reason   string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Results returns the outcome of every task the last RunTask ran or skipped,
+// in the order they ran. Tasks that only depend on others are left out.
+func (proj *Project) Results() []*StageResult {
+	return proj.results
+}
+
+// WriteSummary writes a table of results to w, with the time every task
+// took and the reason skipped tasks were skipped.
+func WriteSummary(w io.Writer, results []*StageResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "STAGE\tSTATUS\tDURATION\tREASON")
+
+	// stages may run in parallel, so the total is the time from the start
+	// of the first stage to the end of the last
+	var start, end time.Time
+	for _, r := range results {
+		duration := "-"
+		if r.Status != StatusSkipped {
+			duration = formatDuration(r.Duration)
+
+			if start.IsZero() || r.Start.Before(start) {
+				start = r.Start
+			}
+			if r.Start.Add(r.Duration).After(end) {
+				end = r.Start.Add(r.Duration)
+			}
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Task, r.Status, duration, r.Reason)
+	}
+	fmt.Fprintf(tw, "total\t\t%s\t\n", formatDuration(end.Sub(start)))
+
+	return tw.Flush()
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+
+	return d.Round(100 * time.Millisecond).String()
+}
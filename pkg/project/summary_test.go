@@ -0,0 +1,72 @@
+package project_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestResults(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		GoLinter:   project.Bool(false),
+		SkipVendor: project.Bool(true),
+	}
+
+	p.SetRunner(&project.RecordingRunner{
+		Err: func(c *project.Command) error {
+			if c.Name == "go" && c.Args[0] == "test" {
+				return errors.New("exit status 1")
+			}
+			return nil
+		},
+	})
+
+	err := p.SetupProject()
+	if err == nil {
+		t.Fatal("expected go test to fail")
+	}
+
+	got := []string{}
+	for _, r := range p.Results() {
+		got = append(got, r.Task+" "+r.Status+" "+r.Reason)
+	}
+
+	expected := []string{
+		"synth passed ",
+		"init passed ",
+		"vendor skipped skipVendor is set",
+		"tidy passed ",
+		"fmt passed ",
+		"lint skipped goLinter is not set",
+		"test failed ",
+		"build skipped not run, test failed",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected results:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	var out bytes.Buffer
+	err = project.WriteSummary(&out, p.Results())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(expected)+2 {
+		t.Fatalf("expected a header, a row per stage and the total, got:\n%s", out.String())
+	}
+	if strings.Fields(lines[0])[0] != "STAGE" || strings.Fields(lines[len(lines)-1])[0] != "total" {
+		t.Errorf("expected a header and the total, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "lint    skipped  -") {
+		t.Errorf("expected skipped stages to have no duration, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,379 @@
+package project
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultTask is run by gojen when no task is given.
+const DefaultTask = "default"
+
+var taskNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9:_-]*$`)
+
+// TaskConfig is a task defined in the tasks field of the config.
+type TaskConfig struct {
+	Description *string   `yaml:"description" json:"description"`
+	DependsOn   *[]string `yaml:"dependsOn" json:"dependsOn"`
+	Steps       *[]string `yaml:"steps" json:"steps"`
+}
+
+// Task is a named unit of work, either one of the built-in stages of gojen or
+// a task defined in the config. Running a task runs the tasks it depends on
+// first.
+type Task struct {
+	Name        string
+	Description string
+	DependsOn   []string
+	// Steps are the shell commands run by a task defined in the config.
+	Steps []string
+	// Builtin is set for the tasks gojen provides.
+	Builtin bool
+	// Stage is the stage failures of the task are reported for.
+	Stage string
+
+	run  func(proj *Project) error
+	skip func(proj *Project) string
+	// check replaces run in check mode, verifying instead of updating.
+	check func(proj *Project) error
+	// after are tasks that must finish first when they are part of the same
+	// run, without being run by this task.
+	after []string
+	// cache is set for tasks that are skipped when their inputs did not
+	// change since they last passed, tools are the commands printing the
+	// versions of the tools they use, and outputs the files they write,
+	// which are checked along with their inputs.
+	cache   bool
+	tools   [][]string
+	outputs func(proj *Project) []string
+}
+
+// builtinTasks are the stages of gojen, in the order the default task runs
+// them.
+var builtinTasks = []*Task{
+	{
+		Name:        "synth",
+		Stage:       StageGenerate,
+		Description: "Generate the license, README, codeowners, workflows, .gitignore and scaffolded sources",
+		run:         (*Project).Synth,
+		check:       (*Project).CheckGenerated,
+	},
+	{
+		Name:        "init",
+		Stage:       StageSetup,
+		Description: "Run go mod init when there is no go.mod",
+		run:         (*Project).InitModule,
+		check:       (*Project).CheckModule,
+		// vendor, tidy and fmt, which all depend on init, read the sources
+		// scaffolded by synth
+		after: []string{"synth"},
+	},
+	{
+		Name:        "vendor",
+		Stage:       StageSetup,
+		Description: "Run go mod vendor",
+		DependsOn:   []string{"init"},
+		run:         (*Project).RunVendor,
+		check:       (*Project).CheckVendor,
+		skip: func(proj *Project) string {
+			if proj.SkipVendor != nil && *proj.SkipVendor {
+				return "skipVendor is set"
+			}
+			if Check && !goVendorOutput() {
+				return "checking vendor needs go mod vendor -o, added in go 1.18"
+			}
+			return ""
+		},
+		cache: true,
+		tools: [][]string{{"go", "version"}},
+	},
+	{
+		Name:        "tidy",
+		Stage:       StageSetup,
+		Description: "Run go mod tidy",
+		DependsOn:   []string{"init", "vendor"},
+		run:         (*Project).RunTidy,
+		check:       (*Project).CheckTidy,
+		skip: func(proj *Project) string {
+			if proj.SkipTidy != nil && *proj.SkipTidy {
+				return "skipTidy is set"
+			}
+			return ""
+		},
+		cache: true,
+		tools: [][]string{{"go", "version"}},
+	},
+	{
+		Name:        "fmt",
+		Stage:       StageSetup,
+		Description: "Run go fmt",
+		DependsOn:   []string{"init"},
+		run:         (*Project).RunFmt,
+		check:       (*Project).CheckFmt,
+		cache:       true,
+		tools:       [][]string{{"go", "version"}},
+	},
+	{
+		Name:        "lint",
+		Stage:       StageLint,
+		Description: "Run golangci-lint",
+		DependsOn:   []string{"vendor", "tidy", "fmt"},
+		run:         (*Project).RunLinter,
+		skip: func(proj *Project) string {
+			switch {
+			case !proj.IsGoLinter():
+				return "goLinter is not set"
+			case CI:
+				return "the workflows lint using the golangci-lint action"
+			}
+			return ""
+		},
+		cache: true,
+		tools: [][]string{{"go", "version"}, {"golangci-lint", "--version"}},
+	},
+	{
+		Name:        "test",
+		Stage:       StageTest,
+		Description: "Run go test",
+		DependsOn:   []string{"vendor", "tidy", "fmt"},
+		run:         (*Project).RunTest,
+		skip: func(proj *Project) string {
+			if !proj.IsGoTest() {
+				return "goTest is false"
+			}
+			return ""
+		},
+		cache: true,
+		tools: [][]string{{"go", "version"}},
+		outputs: func(proj *Project) []string {
+			if proj.IsCodeCov() {
+				return []string{"coverage.txt"}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "build",
+		Stage:       StageBuild,
+		Description: "Run go build",
+		DependsOn:   []string{"vendor", "tidy", "fmt"},
+		run:         (*Project).RunBuild,
+		skip: func(proj *Project) string {
+			if !proj.IsGoBuild() {
+				return "goBuild is false"
+			}
+			return ""
+		},
+		cache: true,
+		tools: [][]string{{"go", "version"}},
+		outputs: func(proj *Project) []string {
+			return []string{proj.buildOutput()}
+		},
+	},
+	{
+		Name:        DefaultTask,
+		Description: "Generate the project files, then lint, test and build the project",
+		DependsOn:   []string{"synth", "lint", "test", "build"},
+	},
+}
+
+func init() {
+	for _, t := range builtinTasks {
+		t.Builtin = true
+	}
+}
+
+// AllTasks returns the built-in tasks followed by the tasks defined in the
+// config, sorted by name.
+func (proj *Project) AllTasks() []*Task {
+	tasks := append([]*Task{}, builtinTasks...)
+
+	names := []string{}
+	for name := range proj.GetTasks() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		tc := proj.GetTasks()[name]
+		if tc == nil {
+			tc = &TaskConfig{}
+		}
+
+		t := &Task{Name: name, Stage: StageTask}
+		if tc.Description != nil {
+			t.Description = *tc.Description
+		}
+		if tc.DependsOn != nil {
+			t.DependsOn = *tc.DependsOn
+		}
+		if tc.Steps != nil {
+			t.Steps = *tc.Steps
+		}
+		tasks = append(tasks, t)
+	}
+
+	return tasks
+}
+
+// Task returns the task called name.
+func (proj *Project) Task(name string) (*Task, bool) {
+	for _, t := range proj.AllTasks() {
+		if t.Name == name {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
+
+// hasWork reports whether t does anything besides depending on other tasks.
+func (t *Task) hasWork() bool {
+	return t.run != nil || len(t.Steps) > 0
+}
+
+// runTask runs t between its pre and post hooks.
+func (proj *Project) runTask(t *Task) error {
+	// hooks can change files, which checking t must not
+	if Check && t.check != nil {
+		return proj.runTaskBody(t)
+	}
+
+	err := proj.runHooks(t, "pre")
+	if err != nil {
+		return err
+	}
+
+	err = proj.runTaskBody(t)
+	if err != nil {
+		return err
+	}
+
+	return proj.runHooks(t, "post")
+}
+
+// runTaskBody checks t with Check set, or runs it.
+func (proj *Project) runTaskBody(t *Task) error {
+	if Check && t.check != nil {
+		if DryRun {
+			printPlan("check", t.Name)
+			return nil
+		}
+		return t.check(proj)
+	}
+
+	if t.run != nil {
+		return t.run(proj)
+	}
+
+	for _, step := range t.Steps {
+		LogInfo(proj.stdout(), "running "+step, t.Name)
+
+		c := proj.command("sh", "-c", step)
+		err := proj.run(c)
+		if err != nil {
+			LogFail(proj.stderr(), "running "+step+" failed", t.Name)
+			return &StageError{Stage: StageTask, Task: t.Name, Err: err}
+		}
+	}
+
+	if len(t.Steps) > 0 {
+		LogSuccess(proj.stdout(), t.Name+" passed", t.Name)
+	}
+
+	return nil
+}
+
+// taskOrder returns the task called name preceded by every task it depends
+// on, directly or indirectly, each of them once.
+func (proj *Project) taskOrder(name string) ([]*Task, error) {
+	tasks := map[string]*Task{}
+	for _, t := range proj.AllTasks() {
+		tasks[t.Name] = t
+	}
+
+	order := []*Task{}
+	done := map[string]bool{}
+
+	var visit func(name string, chain []string) error
+	visit = func(name string, chain []string) error {
+		for _, c := range chain {
+			if c == name {
+				return fmt.Errorf("task dependency cycle: %s", strings.Join(append(chain, name), " -> "))
+			}
+		}
+
+		if done[name] {
+			return nil
+		}
+
+		t, ok := tasks[name]
+		if !ok {
+			if len(chain) > 0 {
+				return fmt.Errorf("task %s depends on unknown task %s", chain[len(chain)-1], name)
+			}
+			return fmt.Errorf("unknown task %s, run gojen tasks to list them", name)
+		}
+
+		for _, dep := range t.DependsOn {
+			err := visit(dep, append(chain, name))
+			if err != nil {
+				return err
+			}
+		}
+
+		done[name] = true
+		order = append(order, t)
+
+		return nil
+	}
+
+	err := visit(name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// checkTasks reports the problems of the tasks defined in the config.
+func (v *validator) checkTasks(proj *Project) {
+	names := []string{}
+	for name := range proj.GetTasks() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := "tasks." + name
+		tc := proj.GetTasks()[name]
+
+		switch {
+		case !taskNameRe.MatchString(name):
+			v.add(path, "is not a valid task name, use lower case letters, digits, -, _ and :")
+			continue
+		case isBuiltinTask(name):
+			v.add(path, "is a built-in task and cannot be redefined")
+			continue
+		case tc == nil || ((tc.Steps == nil || len(*tc.Steps) == 0) && (tc.DependsOn == nil || len(*tc.DependsOn) == 0)):
+			v.add(path, "needs steps or dependsOn")
+			continue
+		}
+
+		_, err := proj.taskOrder(name)
+		if err != nil {
+			v.add(path+".dependsOn", err.Error())
+		}
+	}
+}
+
+func isBuiltinTask(name string) bool {
+	for _, t := range builtinTasks {
+		if t.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
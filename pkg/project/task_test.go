@@ -0,0 +1,113 @@
+package project_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestRunTask(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Tasks: &map[string]*project.TaskConfig{
+			"up": {
+				Steps: project.StringSlice([]string{"echo up >> log"}),
+			},
+			"seed": {
+				DependsOn: project.StringSlice([]string{"up"}),
+				Steps:     project.StringSlice([]string{"echo seed >> log"}),
+			},
+			"integration": {
+				Description: project.String("Run the integration tests"),
+				DependsOn:   project.StringSlice([]string{"up", "seed"}),
+				Steps:       project.StringSlice([]string{"echo integration >> log", "echo done >> log"}),
+			},
+			"fail": {
+				Steps: project.StringSlice([]string{"exit 3", "echo unreachable >> log"}),
+			},
+		},
+	}
+
+	err := p.ValidateConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = p.RunTask("integration")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != "up\nseed\nintegration\ndone\n" {
+		t.Errorf("expected every task to run once in order, got:\n%s", b)
+	}
+
+	err = p.RunTask("fail")
+	if err == nil {
+		t.Error("expected a failing step to fail the task")
+	}
+
+	err = p.RunTask("nope")
+	if err == nil || !strings.Contains(err.Error(), "unknown task nope") {
+		t.Errorf("expected unknown task error, got %v", err)
+	}
+
+	task, ok := p.Task("integration")
+	if !ok || task.Description != "Run the integration tests" || task.Builtin {
+		t.Errorf("unexpected task %+v", task)
+	}
+
+	names := []string{}
+	for _, task := range p.AllTasks() {
+		names = append(names, task.Name)
+	}
+
+	expected := "synth init vendor tidy fmt lint test build default fail integration seed up"
+	if strings.Join(names, " ") != expected {
+		t.Errorf("expected tasks %s, got %s", expected, strings.Join(names, " "))
+	}
+}
+
+func TestValidateTasks(t *testing.T) {
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Tasks: &map[string]*project.TaskConfig{
+			"test":     {Steps: project.StringSlice([]string{"true"})},
+			"Bad Name": {Steps: project.StringSlice([]string{"true"})},
+			"empty":    {},
+			"a":        {DependsOn: project.StringSlice([]string{"b"})},
+			"b":        {DependsOn: project.StringSlice([]string{"a"})},
+			"c":        {DependsOn: project.StringSlice([]string{"missing"})},
+		},
+	}
+
+	err := p.ValidateConfig()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, expected := range []string{
+		"tasks.test: is a built-in task",
+		"tasks.Bad Name: is not a valid task name",
+		"tasks.empty: needs steps or dependsOn",
+		"tasks.a.dependsOn: task dependency cycle: a -> b -> a",
+		"tasks.c.dependsOn: task c depends on unknown task missing",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in:\n%s", expected, err)
+		}
+	}
+}
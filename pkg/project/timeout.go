@@ -0,0 +1,111 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrTimedOut is the error of a stage that ran for longer than its timeout,
+// ErrInterrupted of the stage running when gojen was interrupted.
+var (
+	ErrTimedOut    = errors.New("timed out")
+	ErrInterrupted = errors.New("interrupted")
+)
+
+func (proj *Project) GetTimeouts() map[string]*string {
+	if proj.Timeouts == nil {
+		return map[string]*string{}
+	}
+	return *proj.Timeouts
+}
+
+// SetContext makes the project stop the commands it runs, and not start any
+// other stage, once ctx is done, e.g. when gojen is interrupted.
+func (proj *Project) SetContext(ctx context.Context) {
+	proj.ctx = ctx
+}
+
+func (proj *Project) context() context.Context {
+	if proj.ctx == nil {
+		return context.Background()
+	}
+	return proj.ctx
+}
+
+// timeout returns how long the task called name may run, 0 when it has no
+// timeout.
+func (proj *Project) timeout(name string) time.Duration {
+	s := proj.GetTimeouts()[name]
+	if s == nil {
+		return 0
+	}
+
+	d, err := time.ParseDuration(*s)
+	if err != nil {
+		return 0
+	}
+
+	return d
+}
+
+// stageContext returns the context the commands of t run with, which is done
+// once t runs for longer than its timeout.
+func (proj *Project) stageContext(t *Task) (context.Context, context.CancelFunc) {
+	if d := proj.timeout(t.Name); d > 0 {
+		return context.WithTimeout(proj.context(), d)
+	}
+
+	return context.WithCancel(proj.context())
+}
+
+// stageFailure replaces err, the error of t, with why its commands were
+// stopped when ctx, the context of t, is done.
+func (proj *Project) stageFailure(ctx context.Context, t *Task, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		err = fmt.Errorf("%w after %s", ErrTimedOut, proj.timeout(t.Name))
+	case context.Canceled:
+		err = ErrInterrupted
+	default:
+		return err
+	}
+
+	LogFail(proj.stderr(), err.Error(), t.Name)
+
+	return err
+}
+
+// checkTimeouts checks that every timeout is a positive duration for a task
+// that exists.
+func (v *validator) checkTimeouts(proj *Project) {
+	names := []string{}
+	for name := range proj.GetTimeouts() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := "timeouts." + name
+		if _, ok := proj.Task(name); !ok {
+			v.add(path, "is not a task, see gojen tasks")
+			continue
+		}
+
+		s := proj.GetTimeouts()[name]
+		if s == nil {
+			continue
+		}
+
+		d, err := time.ParseDuration(*s)
+		if err != nil || d <= 0 {
+			v.add(path, fmt.Sprintf("%q is not a valid timeout, expected a duration such as 30s or 10m", *s))
+		}
+	}
+}
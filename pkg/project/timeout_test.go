@@ -0,0 +1,167 @@
+package project_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+// slowProject returns a project with a task slow that starts a process in the
+// background and waits for it, and a task after depending on it.
+func slowProject(t *testing.T) *project.Project {
+	t.Helper()
+
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Tasks: &map[string]*project.TaskConfig{
+			"slow":  {Steps: project.StringSlice([]string{"sleep 10 & wait"})},
+			"after": {DependsOn: project.StringSlice([]string{"slow"}), Steps: project.StringSlice([]string{"true"})},
+		},
+	}
+
+	// the output goes through a pipe, which go waits for every process
+	// writing to it to close, so the background process has to be killed
+	// as well for the task to finish
+	p.SetRunner(&project.RecordingRunner{
+		Err: func(c *project.Command) error {
+			c.Stdout, c.Stderr = &bytes.Buffer{}, &bytes.Buffer{}
+			return project.ExecRunner{}.Run(c)
+		},
+	})
+
+	return p
+}
+
+func TestTimeout(t *testing.T) {
+	p := slowProject(t)
+	p.Timeouts = &map[string]*string{"slow": project.String("100ms")}
+
+	start := time.Now()
+	err := p.RunTask("slow")
+	if !errors.Is(err, project.ErrTimedOut) {
+		t.Fatalf("expected slow to time out, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("expected the processes of slow to be killed, it took %s", time.Since(start))
+	}
+	if !strings.Contains(err.Error(), "task slow: timed out after 100ms") {
+		t.Errorf("expected the timeout to be the reason slow failed, got %q", err)
+	}
+}
+
+func TestTimeoutCleanup(t *testing.T) {
+	p := slowProject(t)
+	p.Timeouts = &map[string]*string{"slow": project.String("100ms")}
+	(*p.Tasks)["slow"].Steps = project.StringSlice([]string{"trap 'echo > cleaned; exit 1' TERM; sleep 10 & wait"})
+
+	err := p.RunTask("slow")
+	if !errors.Is(err, project.ErrTimedOut) {
+		t.Fatalf("expected slow to time out, got %v", err)
+	}
+
+	if _, err := os.Stat("cleaned"); err != nil {
+		t.Errorf("expected slow to clean up before it was killed, got %v", err)
+	}
+}
+
+func TestTimeoutKill(t *testing.T) {
+	p := slowProject(t)
+	p.Timeouts = &map[string]*string{"slow": project.String("100ms")}
+	(*p.Tasks)["slow"].Steps = project.StringSlice([]string{"trap '' TERM; sleep 10"})
+	t.Cleanup(project.SetKillGracePeriod(100 * time.Millisecond))
+
+	start := time.Now()
+	err := p.RunTask("slow")
+	if !errors.Is(err, project.ErrTimedOut) {
+		t.Fatalf("expected slow to time out, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("expected the processes ignoring SIGTERM to be killed, it took %s", time.Since(start))
+	}
+}
+
+// contextRunner runs every command until its Context is done, the way a
+// Runner other than ExecRunner stops them.
+type contextRunner struct{}
+
+func (contextRunner) Run(c *project.Command) error {
+	<-c.Context.Done()
+	return c.Context.Err()
+}
+
+func TestTimeoutRunner(t *testing.T) {
+	p := slowProject(t)
+	p.Timeouts = &map[string]*string{"slow": project.String("100ms")}
+	p.SetRunner(contextRunner{})
+
+	err := p.RunTask("slow")
+	if !errors.Is(err, project.ErrTimedOut) {
+		t.Fatalf("expected the runner to stop slow once it timed out, got %v", err)
+	}
+}
+
+func TestInterrupt(t *testing.T) {
+	p := slowProject(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.SetContext(ctx)
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	err := p.RunTask("after")
+	if !errors.Is(err, project.ErrInterrupted) {
+		t.Fatalf("expected slow to be interrupted, got %v", err)
+	}
+
+	got := []string{}
+	for _, r := range p.Results() {
+		got = append(got, r.Task+" "+r.Status+" "+r.Reason)
+	}
+
+	expected := []string{"slow failed ", "after skipped not run, interrupted"}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected results %q, got %q", expected, got)
+	}
+}
+
+func TestValidateTimeouts(t *testing.T) {
+	p := &project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		Timeouts: &map[string]*string{
+			"test":    project.String("10m"),
+			"build":   project.String("soon"),
+			"lint":    project.String("-1m"),
+			"missing": project.String("1m"),
+		},
+	}
+
+	err := p.ValidateConfig()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, expected := range []string{
+		`timeouts.build: "soon" is not a valid timeout`,
+		`timeouts.lint: "-1m" is not a valid timeout`,
+		"timeouts.missing: is not a task",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in:\n%s", expected, err)
+		}
+	}
+
+	if strings.Contains(err.Error(), "timeouts.test") {
+		t.Errorf("expected the timeout of test to be valid, got:\n%s", err)
+	}
+}
@@ -0,0 +1,599 @@
+package project
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Hunter-Thompson/gojen/pkg/github"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	goVersionRe   = regexp.MustCompile(`^[1-9][0-9]*\.[0-9]+(\.[0-9]+|(beta|rc)[0-9]+)?$`)
+	secretNameRe  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	envVarNameRe  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	pathElementRe = regexp.MustCompile(`^[A-Za-z0-9._~+-]+$`)
+	domainRe      = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)+$`)
+)
+
+// Problem is a single issue found while validating a config.
+type Problem struct {
+	// Path is the JSON path of the offending value, e.g. prependSteps[0].run.
+	Path string
+	// File is the config file the offending value came from.
+	File string
+	// Line and Column are the position of the offending value in File.
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p *Problem) String() string {
+	s := p.Message
+	if p.Path != "" {
+		s = p.Path + ": " + s
+	}
+
+	pos := fmt.Sprintf("%d", p.Line)
+	if p.Column > 0 {
+		pos = fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+
+	switch {
+	case p.File != "" && p.Line > 0:
+		s = fmt.Sprintf("%s (%s line %s)", s, filepath.Base(p.File), pos)
+	case p.Line > 0:
+		s = fmt.Sprintf("%s (line %s)", s, pos)
+	case p.File != "":
+		s = fmt.Sprintf("%s (%s)", s, filepath.Base(p.File))
+	}
+
+	return s
+}
+
+// ValidationError lists every problem found in a config.
+type ValidationError struct {
+	File     string
+	Problems []*Problem
+}
+
+func (e *ValidationError) Error() string {
+	b := &strings.Builder{}
+
+	if e.File != "" {
+		fmt.Fprintf(b, "invalid config %s:", filepath.Base(e.File))
+	} else {
+		fmt.Fprint(b, "invalid config:")
+	}
+
+	for _, p := range e.Problems {
+		fmt.Fprintf(b, "\n  %s", p)
+	}
+
+	return b.String()
+}
+
+func (proj *Project) ValidateConfig() error {
+	v := &validator{
+		root:    proj.raw,
+		origins: proj.origins,
+		format:  ConfigFormat(proj.configFile),
+	}
+
+	if proj.raw != nil {
+		v.checkNode(reflect.TypeOf(Project{}), proj.raw, "")
+	}
+
+	if proj.GetName() == "" {
+		v.add("name", "is required")
+	}
+
+	if proj.GetRepository() == "" {
+		v.add("repository", "is required")
+	} else if err := checkModulePath(proj.GetRepository()); err != nil {
+		v.add("repository", err.Error())
+	}
+
+	if proj.GoVersion != nil && !goVersionRe.MatchString(proj.GetGoVersion()) {
+		v.add("goVersion", fmt.Sprintf("%q is not a valid go version, expected e.g. 1.17 or 1.17.2", proj.GetGoVersion()))
+	}
+
+	if proj.GetPreset() != "" && !Contains(Presets(), proj.GetPreset()) {
+		v.add("preset", fmt.Sprintf("%q is not a preset, expected one of %s", proj.GetPreset(), strings.Join(Presets(), ", ")))
+	}
+
+	if proj.GetLicense() != "" && !Contains(Licenses(), proj.GetLicense()) {
+		v.add("license", fmt.Sprintf("%q is not a supported SPDX id, expected one of %s", proj.GetLicense(), strings.Join(Licenses(), ", ")))
+	}
+
+	if proj.GithubToken != nil && !secretNameRe.MatchString(proj.GetGitHubToken()) {
+		v.add("githubToken", fmt.Sprintf("%q is not a valid secret name", proj.GetGitHubToken()))
+	}
+
+	if proj.DefaultReleaseBranch != nil && proj.GetDefaultReleaseBranch() == "" {
+		v.add("defaultReleaseBranch", "must not be empty")
+	}
+
+	env := []string{}
+	for k := range *proj.GetWorkflowEnv() {
+		env = append(env, k)
+	}
+	sort.Strings(env)
+
+	for _, k := range env {
+		if !envVarNameRe.MatchString(k) {
+			v.add("workflowEnv."+k, "is not a valid environment variable name")
+		}
+	}
+
+	v.checkSteps("prependSteps", proj.PrependSteps)
+	v.checkSteps("apendSteps", proj.AppendSteps)
+	v.checkTestEnv(proj)
+	v.checkTasks(proj)
+	v.checkTimeouts(proj)
+
+	if len(v.problems) > 0 {
+		for _, p := range v.problems {
+			if p.File == proj.configFile {
+				p.File = ""
+			}
+		}
+
+		return &ValidationError{
+			File:     proj.configFile,
+			Problems: v.problems,
+		}
+	}
+
+	return nil
+}
+
+type validator struct {
+	root     *yaml.Node
+	origins  map[string]string
+	format   string
+	problems []*Problem
+}
+
+// add records a problem for path, looking up the position of path in the
+// raw config when there is one.
+func (v *validator) add(path string, msg string) {
+	line, column := 0, 0
+	if n := lookupNode(v.root, path); n != nil {
+		line, column = n.Line, n.Column
+	}
+
+	v.problems = append(v.problems, &Problem{
+		Path:    path,
+		File:    v.fileOf(path),
+		Line:    line,
+		Column:  column,
+		Message: msg,
+	})
+}
+
+func (v *validator) addNode(path string, n *yaml.Node, msg string) {
+	v.problems = append(v.problems, &Problem{
+		Path:    path,
+		File:    v.fileOf(path),
+		Line:    n.Line,
+		Column:  n.Column,
+		Message: msg,
+	})
+}
+
+// fileOf returns the config file the value at path, or its closest parent,
+// came from.
+func (v *validator) fileOf(path string) string {
+	return originOf(v.origins, path)
+}
+
+// formatOf returns the format of the config file the value at path came from,
+// values set with --set are parsed as YAML.
+func (v *validator) formatOf(path string) string {
+	switch file := v.fileOf(path); file {
+	case "":
+	case overrideOrigin:
+		return FormatYAML
+	default:
+		return ConfigFormat(file)
+	}
+
+	return v.format
+}
+
+func (v *validator) checkSteps(path string, steps *[]*github.JobStep) {
+	if steps == nil {
+		return
+	}
+
+	for i, step := range *steps {
+		stepPath := fmt.Sprintf("%s[%d]", path, i)
+
+		if step == nil {
+			v.add(stepPath, "step must not be empty")
+			continue
+		}
+
+		if (step.Run == nil) == (step.Uses == nil) {
+			v.add(stepPath, `step must set exactly one of "run" or "uses"`)
+		}
+	}
+}
+
+// checkNode validates the raw config node n against the Go type t, mirroring
+// how encoding/json and yaml.v3 decode into it.
+func (v *validator) checkNode(t reflect.Type, n *yaml.Node, path string) {
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		nullable = true
+		t = t.Elem()
+	}
+
+	if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!null" {
+		if !nullable && t.Kind() != reflect.Map && t.Kind() != reflect.Slice && t.Kind() != reflect.Interface {
+			v.addNode(path, n, fmt.Sprintf("expected %s, got null", typeName(t)))
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return
+	case reflect.String:
+		if n.Kind != yaml.ScalarNode || (v.formatOf(path) == FormatJSON && n.ShortTag() != "!!str") {
+			v.addNode(path, n, fmt.Sprintf("expected string, got %s", nodeTypeName(n)))
+		}
+	case reflect.Bool:
+		if n.Kind != yaml.ScalarNode || n.ShortTag() != "!!bool" {
+			v.addNode(path, n, fmt.Sprintf("expected boolean, got %s", nodeTypeName(n)))
+		}
+	case reflect.Int, reflect.Int64, reflect.Float32, reflect.Float64:
+		if n.Kind != yaml.ScalarNode || (n.ShortTag() != "!!int" && n.ShortTag() != "!!float") {
+			v.addNode(path, n, fmt.Sprintf("expected number, got %s", nodeTypeName(n)))
+		}
+	case reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			v.addNode(path, n, fmt.Sprintf("expected list, got %s", nodeTypeName(n)))
+			return
+		}
+
+		for i, item := range n.Content {
+			v.checkNode(t.Elem(), item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			v.addNode(path, n, fmt.Sprintf("expected object, got %s", nodeTypeName(n)))
+			return
+		}
+
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v.checkNode(t.Elem(), n.Content[i+1], joinPath(path, n.Content[i].Value))
+		}
+	case reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			v.addNode(path, n, fmt.Sprintf("expected object, got %s", nodeTypeName(n)))
+			return
+		}
+
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i].Value
+			keyPath := joinPath(path, key)
+
+			field, ok := v.field(t, key)
+			if !ok {
+				msg := "unknown key"
+				if s := suggest(key, v.keys(t)); s != "" {
+					msg = fmt.Sprintf("unknown key, did you mean %q?", s)
+				}
+				v.addNode(keyPath, n.Content[i], msg)
+				continue
+			}
+
+			v.checkNode(field.Type, n.Content[i+1], keyPath)
+		}
+	}
+}
+
+// field finds the struct field that key decodes into.
+func (v *validator) field(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name, tagged := fieldKey(f, v.format)
+		if name == key || (!tagged && v.format == FormatJSON && strings.EqualFold(name, key)) {
+			return f, true
+		}
+		if v.format == FormatJSON && fieldNameKeys[t] && strings.EqualFold(f.Name, key) {
+			return f, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// fieldNameKeys are the structs whose fields were untagged in JSON configs,
+// which encoding/json decoded by their field names case insensitively, e.g.
+// Name or ContinueOnError in a step. Those keys stay valid next to the tags.
+var fieldNameKeys = map[reflect.Type]bool{
+	reflect.TypeOf(github.JobStep{}): true,
+}
+
+// canonicalKeys renames the keys of the objects in n that field matches to
+// the keys the decoder expects, e.g. Name to name in a step of a JSON config.
+func (v *validator) canonicalKeys(t reflect.Type, n *yaml.Node) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t.Kind() == reflect.Slice && n.Kind == yaml.SequenceNode:
+		for _, item := range n.Content {
+			v.canonicalKeys(t.Elem(), item)
+		}
+	case t.Kind() == reflect.Map && n.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v.canonicalKeys(t.Elem(), n.Content[i+1])
+		}
+	case t.Kind() == reflect.Struct && n.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			field, ok := v.field(t, n.Content[i].Value)
+			if !ok {
+				continue
+			}
+
+			n.Content[i].Value, _ = fieldKey(field, FormatYAML)
+			v.canonicalKeys(field.Type, n.Content[i+1])
+		}
+	}
+}
+
+func (v *validator) keys(t reflect.Type) []string {
+	keys := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name, _ := fieldKey(f, v.format)
+		keys = append(keys, name)
+	}
+
+	return keys
+}
+
+// fieldKey returns the config key of a struct field for the given format,
+// and whether it was set explicitly through a struct tag.
+func fieldKey(f reflect.StructField, format string) (string, bool) {
+	tag := f.Tag.Get("json")
+	if format == FormatYAML {
+		tag = f.Tag.Get("yaml")
+	}
+
+	name := strings.Split(tag, ",")[0]
+	if name != "" && name != "-" {
+		return name, true
+	}
+
+	if format == FormatYAML {
+		return strings.ToLower(f.Name), false
+	}
+
+	return f.Name, false
+}
+
+// lookupNode finds the value node at a path such as prependSteps[0].run.
+func lookupNode(root *yaml.Node, path string) *yaml.Node {
+	n := root
+	for _, part := range splitPath(path) {
+		if n == nil {
+			return nil
+		}
+
+		if n.Kind == yaml.AliasNode {
+			n = n.Alias
+		}
+
+		switch {
+		case part.index >= 0 && n.Kind == yaml.SequenceNode:
+			if part.index >= len(n.Content) {
+				return nil
+			}
+			n = n.Content[part.index]
+		case part.index < 0 && n.Kind == yaml.MappingNode:
+			n = mappingValue(n, part.key)
+		default:
+			return nil
+		}
+	}
+
+	return n
+}
+
+type pathPart struct {
+	key   string
+	index int
+}
+
+// splitPath splits a path such as prependSteps[0].run into its parts.
+func splitPath(path string) []pathPart {
+	parts := []pathPart{}
+	if path == "" {
+		return parts
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		key := segment
+		indexes := ""
+		if i := strings.Index(segment, "["); i >= 0 {
+			key = segment[:i]
+			indexes = segment[i:]
+		}
+
+		if key != "" {
+			parts = append(parts, pathPart{key: key, index: -1})
+		}
+
+		for indexes != "" {
+			end := strings.Index(indexes, "]")
+			if end < 0 {
+				break
+			}
+
+			i := 0
+			fmt.Sscanf(indexes[1:end], "%d", &i)
+			parts = append(parts, pathPart{index: i})
+			indexes = indexes[end+1:]
+		}
+	}
+
+	return parts
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int64, reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		return "list"
+	default:
+		return "object"
+	}
+}
+
+func nodeTypeName(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.SequenceNode:
+		return "list"
+	case yaml.MappingNode:
+		return "object"
+	}
+
+	switch n.ShortTag() {
+	case "!!str":
+		return "string"
+	case "!!bool":
+		return "boolean"
+	case "!!int", "!!float":
+		return "number"
+	case "!!null":
+		return "null"
+	}
+
+	return n.ShortTag()
+}
+
+// checkModulePath reports whether path is a valid go module path, following
+// the rules of `go mod init` for paths that can be fetched.
+func checkModulePath(path string) error {
+	if strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") {
+		return fmt.Errorf("%q is not a valid module path, it must not start or end with a slash", path)
+	}
+
+	elems := strings.Split(path, "/")
+	for _, elem := range elems {
+		if elem == "" {
+			return fmt.Errorf("%q is not a valid module path, it contains an empty path element", path)
+		}
+
+		if elem == "." || elem == ".." || strings.HasPrefix(elem, ".") || strings.HasSuffix(elem, ".") {
+			return fmt.Errorf("%q is not a valid module path, path elements must not start or end with a dot", path)
+		}
+
+		if !pathElementRe.MatchString(elem) {
+			return fmt.Errorf("%q is not a valid module path, %q contains invalid characters", path, elem)
+		}
+	}
+
+	if !domainRe.MatchString(elems[0]) {
+		return fmt.Errorf("%q is not a valid module path, it must start with a lowercase domain such as github.com", path)
+	}
+
+	return nil
+}
+
+// suggest returns the candidate closest to key, or an empty string when none
+// of them is close enough to be a likely typo.
+func suggest(key string, candidates []string) string {
+	best := ""
+	bestDistance := -1
+
+	for _, c := range candidates {
+		d := levenshtein(strings.ToLower(key), strings.ToLower(c))
+		if bestDistance < 0 || d < bestDistance {
+			best = c
+			bestDistance = d
+		}
+	}
+
+	limit := len(key) / 3
+	if limit < 2 {
+		limit = 2
+	}
+
+	if bestDistance < 0 || bestDistance > limit {
+		return ""
+	}
+
+	return best
+}
+
+func levenshtein(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+func min3(a int, b int, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+
+	return m
+}
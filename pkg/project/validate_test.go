@@ -0,0 +1,132 @@
+package project_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+	"github.com/bradleyjkemp/cupaloy/v2"
+)
+
+func TestValidateConfig(t *testing.T) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotter := cupaloy.New(cupaloy.SnapshotSubdirectory(filepath.Join(pwd, ".snapshots")))
+
+	configs := map[string]string{
+		"gojen.json": `{
+  "name": "",
+  "repository": "Test/test",
+  "goVersion": "latest",
+  "license": "MIT-2.0",
+  "githubToken": "GIT TOKEN",
+  "goTest": "yes",
+  "appendSteps": [],
+  "gitignore": "dist",
+  "workflowEnv": {
+    "FOO": "bar",
+    "NOT-VALID": "baz"
+  },
+  "prependSteps": [
+    {"name": "both", "run": "make", "uses": "actions/checkout@v2"},
+    {"name": "neither"},
+    {"name": "typo", "runs": "make"}
+  ]
+}
+`,
+		"gojen.yaml": `name: test
+repository: github.com/test/test
+goVersion: 1.17
+goTestArgs: -v
+apendSteps:
+  - uses: actions/checkout@v2
+    with:
+      fetch-depth: 0
+  - run: make
+    continue-on-eror: true
+`,
+	}
+
+	for name, contents := range configs {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			chdir(t, dir)
+
+			err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = project.InitProject()
+
+			var validationErr *project.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+
+			err = snapshotter.SnapshotMulti(name, err.Error())
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValid(t *testing.T) {
+	p := project.Project{
+		Name:       project.String("test"),
+		Repository: project.String("github.com/test/test"),
+		GoVersion:  project.String("1.17.2"),
+		License:    project.String("LGPL-2.1-or-later"),
+	}
+
+	err := p.ValidateConfig()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateConfigStepFieldNames(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	// steps used to be decoded by encoding/json into untagged fields
+	writeFiles(t, dir, map[string]string{
+		"gojen.json": `{
+  "name": "test",
+  "repository": "github.com/test/test",
+  "prependSteps": [
+    {"Name": "x", "Run": "echo hi", "ContinueOnError": true, "TimeoutMinutes": 5},
+    {"name": "y", "uses": "actions/checkout@v2", "continue-on-error": true}
+  ]
+}
+`,
+	})
+
+	p, err := project.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = p.ValidateConfig()
+	if err != nil {
+		t.Fatalf("expected the field names of steps to be valid keys, got %v", err)
+	}
+
+	steps := *p.PrependSteps
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(steps))
+	}
+	if s := steps[0]; s.Name == nil || *s.Name != "x" || s.Run == nil || *s.Run != "echo hi" ||
+		s.ContinueOnError == nil || !*s.ContinueOnError || s.TimeoutMinutes == nil || *s.TimeoutMinutes != 5 {
+		t.Errorf("expected the step written with field names to be decoded, got %+v", s)
+	}
+	if s := steps[1]; s.Name == nil || *s.Name != "y" || s.ContinueOnError == nil || !*s.ContinueOnError {
+		t.Errorf("expected the step written with its tags to be decoded, got %+v", s)
+	}
+}
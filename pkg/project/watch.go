@@ -0,0 +1,270 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WatchInterval is how often Watch polls the files of the project, and
+// WatchDebounce how long they must stay unchanged before the stages run, so
+// saving several files at once results in a single run.
+var (
+	WatchInterval = 500 * time.Millisecond
+	WatchDebounce = 300 * time.Millisecond
+)
+
+// watchTicks returns the channel telling Watch to poll, and a func stopping
+// it, and watchWaiting is called whenever Watch waits for the next poll. The
+// tests replace both to drive Watch one poll at a time.
+var (
+	watchTicks = func() (<-chan time.Time, func()) {
+		ticker := time.NewTicker(WatchInterval)
+		return ticker.C, ticker.Stop
+	}
+	watchWaiting = func() {}
+)
+
+// fileState is what Watch compares to find the files that changed.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watch polls the Go sources, go.mod, go.sum and the config file of the
+// project until stop is closed, and reruns the stages affected by every
+// change. With clear set the screen is cleared before every run.
+func (proj *Project) Watch(stop <-chan struct{}, clear bool) error {
+	files, err := proj.watchedFiles()
+	if err != nil {
+		return err
+	}
+
+	proj.drawStatus(clear, nil, files)
+
+	ticks, stopTicks := watchTicks()
+	defer stopTicks()
+
+	changed := map[string]bool{}
+	var lastChange time.Time
+
+	for {
+		watchWaiting()
+
+		select {
+		case <-stop:
+			return nil
+		case <-ticks:
+		}
+
+		// files can disappear while they are listed, the next poll sees
+		// the change
+		current, err := proj.watchedFiles()
+		if err != nil {
+			continue
+		}
+
+		diff := changedFiles(files, current)
+		files = current
+		if len(diff) > 0 {
+			for _, f := range diff {
+				changed[f] = true
+			}
+			lastChange = time.Now()
+			continue
+		}
+
+		if len(changed) == 0 || time.Since(lastChange) < WatchDebounce {
+			continue
+		}
+
+		paths := []string{}
+		for f := range changed {
+			paths = append(paths, f)
+		}
+		sort.Strings(paths)
+		changed = map[string]bool{}
+
+		proj.drawStatus(clear, paths, nil)
+		proj.rerun(paths)
+
+		// the stages write files too, e.g. go mod tidy, which must not
+		// trigger another run
+		if current, err := proj.watchedFiles(); err == nil {
+			files = current
+		}
+		proj.drawStatus(false, nil, files)
+	}
+}
+
+// rerun runs the stages affected by the paths that changed, reloading the
+// config first when it is one of them.
+func (proj *Project) rerun(paths []string) {
+	cfg, _ := proj.ConfigFile()
+	if Contains(paths, cfg) {
+		err := proj.reload()
+		if err != nil {
+			LogFail(proj.stderr(), err.Error(), "Watch")
+			return
+		}
+	}
+
+	stages := proj.affectedStages(paths)
+	if len(stages) == 0 {
+		return
+	}
+
+	only := Only
+	Only = stages
+	err := proj.RunTask(DefaultTask)
+	Only = only
+
+	results := []*StageResult{}
+	for _, r := range proj.results {
+		if r.Reason != notSelectedReason {
+			results = append(results, r)
+		}
+	}
+
+	// with OutputJSON the events of the stages report how they went
+	if Output == OutputText {
+		fmt.Fprintln(proj.stdout())
+		_ = WriteSummary(proj.stdout(), results)
+	}
+
+	if err != nil {
+		fmt.Fprintln(proj.stderr(), err.Error())
+	}
+}
+
+// reload replaces the config of proj with the one on disk, keeping how it
+// runs its commands. An invalid config leaves proj as it was.
+func (proj *Project) reload() error {
+	next := &Project{configFile: proj.configFile}
+	err := next.load()
+	if err != nil {
+		return err
+	}
+
+	err = next.ValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	next.runner, next.ctx, next.out, next.errOut = proj.runner, proj.ctx, proj.out, proj.errOut
+	*proj = *next
+
+	return nil
+}
+
+// affectedStages returns the stages of the default task to rerun after paths
+// changed, in the order they run: synth for the config, which generates the
+// workflows, vendor and tidy for the module files, test for the tests and
+// test data, and test and build for the rest of the sources. Stages the config
+// skips, or that --only leaves out, are not rerun.
+func (proj *Project) affectedStages(paths []string) []string {
+	cfg, _ := proj.ConfigFile()
+
+	affected := map[string]bool{}
+	for _, p := range paths {
+		name := filepath.Base(p)
+		switch {
+		case p == cfg:
+			affected["synth"] = true
+		case p == "go.mod" || p == "go.sum" || p == filepath.Join("vendor", "modules.txt"):
+			affected["vendor"] = true
+			affected["tidy"] = true
+			affected["test"] = true
+			affected["build"] = true
+		case strings.HasSuffix(name, "_test.go") || !strings.HasSuffix(name, ".go"):
+			affected["test"] = true
+		default:
+			affected["test"] = true
+			affected["build"] = true
+		}
+	}
+
+	stages := []string{}
+	for _, t := range builtinTasks {
+		if !affected[t.Name] || (len(Only) > 0 && !Contains(Only, t.Name)) {
+			continue
+		}
+		if t.skip != nil && t.skip(proj) != "" {
+			continue
+		}
+		stages = append(stages, t.Name)
+	}
+
+	return stages
+}
+
+// watchedFiles returns the state of the files Watch polls: the inputs of the
+// stages, see inputFiles, and the config file.
+func (proj *Project) watchedFiles() (map[string]fileState, error) {
+	paths, err := inputFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := proj.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	paths = append(paths, cfg)
+
+	files := map[string]fileState{}
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		files[p] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	return files, nil
+}
+
+// changedFiles returns the files that were created, changed or removed
+// between two polls, sorted.
+func changedFiles(before map[string]fileState, after map[string]fileState) []string {
+	changed := []string{}
+	for p, state := range after {
+		if old, ok := before[p]; !ok || old != state {
+			changed = append(changed, p)
+		}
+	}
+	for p := range before {
+		if _, ok := after[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	sort.Strings(changed)
+
+	return changed
+}
+
+// drawStatus prints what Watch is doing: the files that changed before a run,
+// or how many files it watches while it waits.
+func (proj *Project) drawStatus(clear bool, changed []string, files map[string]fileState) {
+	if clear {
+		fmt.Fprint(proj.textOut(), "\033[H\033[2J")
+	}
+
+	now := time.Now().Format("15:04:05")
+	if len(changed) > 0 {
+		names := []string{}
+		for _, p := range changed {
+			names = append(names, relPath(p))
+		}
+		fmt.Fprintf(proj.textOut(), "[%s] changed: %s\n\n", now, strings.Join(names, ", "))
+		return
+	}
+
+	fmt.Fprintf(proj.textOut(), "\n[%s] watching %d files for changes, press Ctrl-C to stop\n", now, len(files))
+}
@@ -0,0 +1,83 @@
+package project_test
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"gojen.json":   `{"name": "test", "repository": "github.com/test/test", "goLinter": false}`,
+		"go.mod":       "module github.com/test/test\n\ngo 1.17\n",
+		"main.go":      "package main\n\nfunc main() {}\n",
+		"main_test.go": "package main\n",
+	})
+	chdir(t, dir)
+
+	// Watch polls when the test ticks, and runs the stages on the first poll
+	// without changes
+	debounce := project.WatchDebounce
+	project.WatchDebounce = 0
+	ticks, waiting := make(chan time.Time), make(chan struct{})
+	restore := project.SetWatchTicks(ticks, waiting)
+	t.Cleanup(func() {
+		project.WatchDebounce = debounce
+		restore()
+	})
+
+	p, err := project.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner := &project.RecordingRunner{}
+	p.SetRunner(runner)
+
+	stop := make(chan struct{})
+	stopped := make(chan error)
+	go func() {
+		stopped <- p.Watch(stop, false)
+	}()
+
+	// Watch lists the files before it first waits
+	<-waiting
+
+	// waitFor writes a file, and checks the commands run since the start of
+	// the test once Watch polled the change and then ran the stages
+	waitFor := func(file string, content string, expected []string) {
+		t.Helper()
+
+		err := ioutil.WriteFile(file, []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 2; i++ {
+			ticks <- time.Now()
+			<-waiting
+		}
+
+		if got := runner.Strings(); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("expected commands %q after %s changed, got %q", expected, file, got)
+		}
+	}
+
+	waitFor("main_test.go", "package main\n\n// a test\n", []string{"go test"})
+
+	waitFor("main.go", "package main\n\nfunc main() { println() }\n", []string{"go test", "go test", "go build"})
+
+	// the config is reloaded, so the changes after it no longer run go test
+	waitFor("gojen.json", `{"name": "test", "repository": "github.com/test/test", "goLinter": false, "goTest": false}`, []string{"go test", "go test", "go build"})
+
+	waitFor("main.go", "package main\n\nfunc main() {}\n", []string{"go test", "go test", "go build", "go build"})
+
+	close(stop)
+	if err := <-stopped; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,205 @@
+package project
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var remoteRe = regexp.MustCompile(`^(?:https?://|ssh://)?(?:[^@/]+@)?([^/:]+)[:/](.+?)(?:\.git)?/?$`)
+
+// Wizard asks for the settings of a new project on in, writing its prompts to
+// out. Answers default to the current values of proj, or to values inferred
+// from dir and its git config when they are not set. The resulting config is
+// previewed before asking for confirmation, Wizard reports whether it was
+// given.
+func (proj *Project) Wizard(in io.Reader, out io.Writer, dir string) (bool, error) {
+	w := &wizard{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+
+	name := proj.GetName()
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+	proj.Name = String(w.ask("Project name", name, func(s string) error {
+		if s == "" {
+			return errors.New("is required")
+		}
+		return nil
+	}))
+
+	repository := proj.GetRepository()
+	if repository == "" {
+		repository = inferRepository(dir, proj.GetName())
+	}
+	proj.Repository = String(w.ask("Go module path", repository, checkModulePath))
+
+	proj.Description = String(w.ask("Description", proj.GetDescription(), nil))
+
+	authorName := proj.GetAuthorName()
+	if authorName == "" {
+		authorName = gitConfig(dir, "user.name")
+	}
+	proj.AuthorName = String(w.ask("Author name", authorName, nil))
+
+	authorEmail := proj.GetAuthorEmail()
+	if authorEmail == "" {
+		authorEmail = gitConfig(dir, "user.email")
+	}
+	proj.AuthorEmail = String(w.ask("Author email", authorEmail, nil))
+
+	if proj.GetAuthorOrganization() == "" {
+		parts := strings.Split(proj.GetRepository(), "/")
+		if len(parts) > 1 && strings.Contains(parts[0], ".") {
+			proj.AuthorOrganization = String(parts[1])
+		}
+	}
+
+	licenses := Licenses()
+	fmt.Fprintln(out, "Licenses:")
+	for i, l := range licenses {
+		fmt.Fprintf(out, "  %2d) %s\n", i+1, l)
+	}
+	proj.License = String(w.ask("License (number, SPDX id or none)", proj.GetLicense(), func(s string) error {
+		if s == "" || s == "none" || Contains(licenses, s) {
+			return nil
+		}
+		if i, err := strconv.Atoi(s); err == nil && i > 0 && i <= len(licenses) {
+			return nil
+		}
+		return fmt.Errorf("%q is not one of the listed licenses", s)
+	}))
+	if i, err := strconv.Atoi(proj.GetLicense()); err == nil {
+		proj.License = String(licenses[i-1])
+	}
+	if proj.GetLicense() == "none" {
+		proj.License = String("")
+	}
+
+	proj.Release = Bool(w.confirm("Create release workflows", proj.IsRelease()))
+	proj.BuildWorkflow = Bool(w.confirm("Create pull request build workflow", proj.IsBuildWorkflow()))
+	proj.GoLinter = Bool(w.confirm("Lint with golangci-lint", proj.IsGoLinter()))
+	proj.CodeCov = Bool(w.confirm("Upload test coverage to codecov", proj.IsCodeCov()))
+
+	if w.err != nil {
+		return false, w.err
+	}
+
+	b, err := proj.MarshalConfig()
+	if err != nil {
+		return false, err
+	}
+
+	cfgPath, err := proj.ConfigFile()
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Fprintf(out, "\n%s\n", b)
+	ok := w.confirm(fmt.Sprintf("Write %s", filepath.Base(cfgPath)), true)
+
+	return ok, w.err
+}
+
+type wizard struct {
+	in  *bufio.Reader
+	out io.Writer
+	err error
+}
+
+// ask prompts for a value until check accepts it, an empty answer picks def.
+func (w *wizard) ask(prompt string, def string, check func(string) error) string {
+	for w.err == nil {
+		if def != "" {
+			fmt.Fprintf(w.out, "%s [%s]: ", prompt, def)
+		} else {
+			fmt.Fprintf(w.out, "%s: ", prompt)
+		}
+
+		answer, err := w.in.ReadString('\n')
+		if err != nil && (err != io.EOF || answer == "") {
+			if err == io.EOF {
+				err = fmt.Errorf("no answer for %q", prompt)
+			}
+			w.err = err
+			return def
+		}
+
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			answer = def
+		}
+
+		if check == nil {
+			return answer
+		}
+
+		err = check(answer)
+		if err == nil {
+			return answer
+		}
+		fmt.Fprintf(w.out, "  %s\n", err.Error())
+	}
+
+	return def
+}
+
+// confirm asks a yes or no question.
+func (w *wizard) confirm(prompt string, def bool) bool {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+
+	answer := w.ask(fmt.Sprintf("%s? [%s]", prompt, choices), "", func(s string) error {
+		switch strings.ToLower(s) {
+		case "", "y", "yes", "n", "no":
+			return nil
+		}
+		return errors.New("answer y or n")
+	})
+
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	}
+
+	return def
+}
+
+// gitConfig returns the value of key in the git config of dir, if any.
+func gitConfig(dir string, key string) string {
+	cmd := exec.Command("git", "config", "--get", key)
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+// inferRepository guesses the module path of the project in dir from its git
+// remote, or from the GitHub user in the git config.
+func inferRepository(dir string, name string) string {
+	if m := remoteRe.FindStringSubmatch(gitConfig(dir, "remote.origin.url")); m != nil {
+		return m[1] + "/" + m[2]
+	}
+
+	if user := gitConfig(dir, "github.user"); user != "" {
+		return "github.com/" + user + "/" + name
+	}
+
+	return ""
+}
@@ -0,0 +1,92 @@
+package project_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Hunter-Thompson/gojen/pkg/project"
+)
+
+func TestWizard(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	answers := strings.Join([]string{
+		"",                     // name defaults to the directory name
+		"not a module",         // rejected
+		"github.com/acme/tool", // module path
+		"A tool",
+		"Jane Doe",
+		"jane@example.com",
+		"MIT",
+		"y",
+		"",
+		"maybe", // rejected
+		"n",
+		"yes",
+		"", // write the config
+	}, "\n") + "\n"
+
+	p := &project.Project{}
+	out := &bytes.Buffer{}
+
+	ok, err := p.Wizard(strings.NewReader(answers), out, "/src/tool")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok {
+		t.Error("expected the config to be confirmed")
+	}
+
+	if p.GetName() != "tool" || p.GetRepository() != "github.com/acme/tool" || p.GetAuthorOrganization() != "acme" {
+		t.Errorf("unexpected project %s %s %s", p.GetName(), p.GetRepository(), p.GetAuthorOrganization())
+	}
+
+	if p.GetLicense() != "MIT" || !p.IsRelease() || p.IsBuildWorkflow() || p.IsGoLinter() || !p.IsCodeCov() {
+		t.Errorf("unexpected answers license %s release %v build %v lint %v codecov %v",
+			p.GetLicense(), p.IsRelease(), p.IsBuildWorkflow(), p.IsGoLinter(), p.IsCodeCov())
+	}
+
+	for _, expected := range []string{
+		"Project name [tool]: ",
+		"is not a valid module path",
+		"answer y or n",
+		`"repository": "github.com/acme/tool"`,
+		"Write gojen.json? [Y/n]: ",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q in:\n%s", expected, out)
+		}
+	}
+}
+
+func TestWizardLicenseNumber(t *testing.T) {
+	chdir(t, t.TempDir())
+
+	p := &project.Project{
+		Name:       project.String("tool"),
+		Repository: project.String("github.com/acme/tool"),
+	}
+
+	answers := "\n\n\n\n\n1\n\n\n\n\nn\n"
+
+	ok, err := p.Wizard(strings.NewReader(answers), &bytes.Buffer{}, "/src/tool")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok {
+		t.Error("expected the config to be declined")
+	}
+
+	if p.GetLicense() != project.Licenses()[0] {
+		t.Errorf("expected license %s, got %s", project.Licenses()[0], p.GetLicense())
+	}
+
+	_, err = p.Wizard(strings.NewReader(""), &bytes.Buffer{}, "/src/tool")
+	if err == nil {
+		t.Error("expected an error when input ends")
+	}
+}